@@ -0,0 +1,86 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dioad/cli"
+)
+
+// loginConfig is the (empty) typed config threaded through cli.NewCommand
+// for the login/logout commands; device login takes no user-configurable
+// options beyond the provider and scopes supplied at registration time.
+type loginConfig struct{}
+
+// NewDeviceLoginCommand returns a pair of "login" and "logout" cobra
+// commands that run provider's device authorization flow and persist the
+// resulting access token via the existing DefaultPersistencePath, using the
+// OS keyring when available and falling back to a file on disk.
+//
+// Typical usage:
+//
+//	loginCmd, logoutCmd := auth.NewDeviceLoginCommand(orgName, appName, auth.GitHubProvider(clientID, ""), []string{"repo"})
+//	rootCmd.AddCommand(loginCmd, logoutCmd)
+func NewDeviceLoginCommand(orgName, appName string, provider DeviceAuthenticator, scopes []string) (*cobra.Command, *cobra.Command) {
+	providerName := providerName(provider)
+
+	loginCmd := cli.NewCommand(
+		&cobra.Command{
+			Use:   "login",
+			Short: fmt.Sprintf("Log in to %s", providerName),
+		},
+		func(ctx context.Context, _ *loginConfig) error {
+			store, err := newTokenStoreFor(orgName, appName, providerName)
+			if err != nil {
+				return err
+			}
+
+			token, err := provider.DeviceLogin(scopes)
+			if err != nil {
+				return fmt.Errorf("error running device login: %w", err)
+			}
+
+			return store.SaveToken(token.Token)
+		},
+		&loginConfig{},
+	)
+
+	logoutCmd := cli.NewCommand(
+		&cobra.Command{
+			Use:   "logout",
+			Short: fmt.Sprintf("Log out of %s", providerName),
+		},
+		func(ctx context.Context, _ *loginConfig) error {
+			store, err := newTokenStoreFor(orgName, appName, providerName)
+			if err != nil {
+				return err
+			}
+
+			return store.DeleteToken()
+		},
+		&loginConfig{},
+	)
+
+	return loginCmd, logoutCmd
+}
+
+func newTokenStoreFor(orgName, appName, providerName string) (TokenStore, error) {
+	persistencePath, err := cli.DefaultPersistencePath(orgName, appName)
+	if err != nil {
+		return nil, fmt.Errorf("error resolving persistence path: %w", err)
+	}
+
+	return NewTokenStore(orgName, providerName, persistencePath), nil
+}
+
+func providerName(provider DeviceAuthenticator) string {
+	if p, ok := provider.(Provider); ok && p.Name != "" {
+		return p.Name
+	}
+	if p, ok := provider.(interface{ Name() string }); ok {
+		return p.Name()
+	}
+	return "provider"
+}
@@ -3,7 +3,6 @@ package auth
 import (
 	"fmt"
 
-	"github.com/cli/oauth"
 	"github.com/cli/oauth/api"
 )
 
@@ -19,50 +18,14 @@ func browseURL(uri string) error {
 	return nil
 }
 
-func gitHubHeadlessDeviceLogin(clientID string, scopes []string) (*api.AccessToken, error) {
-
-	host := oauth.GitHubHost("github.com")
-
-	flow := &oauth.Flow{
-		Host:     host,
-		ClientID: clientID,
-		Scopes:   scopes,
-	}
-
-	flow.DisplayCode = displayCode
-	flow.BrowseURL = browseURL
-
-	accessToken, err := flow.DeviceFlow()
-	if err != nil {
-		return nil, err
-	}
-
-	return accessToken, nil
-}
-
-func gitHubDeviceLogin(clientID string, scopes []string) (*api.AccessToken, error) {
-	host := oauth.GitHubHost("github.com")
-	flow := &oauth.Flow{
-		Host:     host,
-		ClientID: clientID,
-		Scopes:   scopes,
-	}
-
-	accessToken, err := flow.DeviceFlow()
-	if err != nil {
-		return nil, err
-	}
-
-	return accessToken, nil
-}
-
+// GitHubDeviceLogin runs the device authorization flow against github.com.
+//
+// Deprecated: use GitHubProvider(clientID, "").DeviceLogin(scopes) instead,
+// which allows the provider to be swapped out or pointed at a GitHub
+// Enterprise host.
 func GitHubDeviceLogin(clientID string, scopes []string, headless bool) (*api.AccessToken, error) {
-	var deviceLoginFunc DeviceLoginFunc
-	if headless {
-		deviceLoginFunc = gitHubHeadlessDeviceLogin
-	} else {
-		deviceLoginFunc = gitHubDeviceLogin
-	}
+	provider := GitHubProvider(clientID, "")
+	provider.Headless = headless
 
-	return deviceLoginFunc(clientID, scopes)
+	return provider.DeviceLogin(scopes)
 }
@@ -0,0 +1,103 @@
+package auth
+
+import (
+	"fmt"
+
+	"github.com/cli/oauth"
+	"github.com/cli/oauth/api"
+)
+
+// DeviceAuthenticator performs an OAuth 2.0 Device Authorization Grant
+// (RFC 8628) flow against a specific provider and returns an access token.
+//
+// Implementations are expected to be stateless and safe to reuse across
+// multiple login attempts.
+type DeviceAuthenticator interface {
+	DeviceLogin(scopes []string) (*api.AccessToken, error)
+}
+
+// Provider describes the endpoints and client credentials required to run
+// a device authorization flow against any RFC 8628-compliant OAuth server,
+// e.g. GitHub, GitLab, Keycloak, Auth0 or Okta.
+type Provider struct {
+	// Name identifies the provider, e.g. for token store namespacing.
+	Name string
+
+	// ClientID is the OAuth client ID registered with the provider.
+	ClientID string
+
+	// DeviceAuthorizationURL is the provider's device authorization endpoint.
+	DeviceAuthorizationURL string
+
+	// TokenURL is the provider's token endpoint.
+	TokenURL string
+
+	// Headless disables the interactive browser prompt and code display,
+	// relying on the provider to handle user interaction out of band.
+	Headless bool
+}
+
+// DeviceLogin implements DeviceAuthenticator.
+func (p Provider) DeviceLogin(scopes []string) (*api.AccessToken, error) {
+	host := &oauth.Host{
+		DeviceCodeURL: p.DeviceAuthorizationURL,
+		TokenURL:      p.TokenURL,
+	}
+
+	flow := &oauth.Flow{
+		Host:     host,
+		ClientID: p.ClientID,
+		Scopes:   scopes,
+	}
+
+	if !p.Headless {
+		flow.DisplayCode = displayCode
+		flow.BrowseURL = browseURL
+	}
+
+	return flow.DeviceFlow()
+}
+
+// GitHubProvider returns a Provider configured for device login against
+// github.com, or a GitHub Enterprise instance when hostname is set.
+func GitHubProvider(clientID, hostname string) Provider {
+	if hostname == "" {
+		hostname = "github.com"
+	}
+
+	host := oauth.GitHubHost(fmt.Sprintf("https://%s", hostname))
+
+	return Provider{
+		Name:                   "github",
+		ClientID:               clientID,
+		DeviceAuthorizationURL: host.DeviceCodeURL,
+		TokenURL:               host.TokenURL,
+	}
+}
+
+// GitLabProvider returns a Provider configured for device login against
+// gitlab.com, or a self-hosted GitLab instance when hostname is set.
+func GitLabProvider(clientID, hostname string) Provider {
+	if hostname == "" {
+		hostname = "gitlab.com"
+	}
+
+	return Provider{
+		Name:                   "gitlab",
+		ClientID:               clientID,
+		DeviceAuthorizationURL: fmt.Sprintf("https://%s/oauth/authorize_device", hostname),
+		TokenURL:               fmt.Sprintf("https://%s/oauth/token", hostname),
+	}
+}
+
+// NewProvider returns a Provider for any RFC 8628-compliant OAuth server,
+// such as Keycloak, Auth0 or Okta, given its device-authorization and token
+// endpoints.
+func NewProvider(name, clientID, deviceAuthorizationURL, tokenURL string) Provider {
+	return Provider{
+		Name:                   name,
+		ClientID:               clientID,
+		DeviceAuthorizationURL: deviceAuthorizationURL,
+		TokenURL:               tokenURL,
+	}
+}
@@ -0,0 +1,49 @@
+package auth_test
+
+import (
+	"testing"
+
+	"github.com/dioad/cli/auth"
+)
+
+// TestGitHubProvider verifies the default github.com provider's name and
+// that both endpoints are set.
+func TestGitHubProvider(t *testing.T) {
+	p := auth.GitHubProvider("client-id", "")
+
+	if p.Name != "github" {
+		t.Errorf("Name = %s, want github", p.Name)
+	}
+
+	if p.DeviceAuthorizationURL == "" || p.TokenURL == "" {
+		t.Error("expected device authorization and token URLs to be set")
+	}
+}
+
+// TestGitLabProvider verifies endpoint construction for self-hosted instances.
+func TestGitLabProvider(t *testing.T) {
+	p := auth.GitLabProvider("client-id", "gitlab.example.com")
+
+	wantDeviceURL := "https://gitlab.example.com/oauth/authorize_device"
+	if p.DeviceAuthorizationURL != wantDeviceURL {
+		t.Errorf("DeviceAuthorizationURL = %s, want %s", p.DeviceAuthorizationURL, wantDeviceURL)
+	}
+
+	wantTokenURL := "https://gitlab.example.com/oauth/token"
+	if p.TokenURL != wantTokenURL {
+		t.Errorf("TokenURL = %s, want %s", p.TokenURL, wantTokenURL)
+	}
+}
+
+// TestNewProvider verifies a generic RFC 8628 provider is built as given.
+func TestNewProvider(t *testing.T) {
+	p := auth.NewProvider("keycloak", "client-id", "https://idp.example.com/device", "https://idp.example.com/token")
+
+	if p.Name != "keycloak" {
+		t.Errorf("Name = %s, want keycloak", p.Name)
+	}
+
+	if p.ClientID != "client-id" {
+		t.Errorf("ClientID = %s, want client-id", p.ClientID)
+	}
+}
@@ -0,0 +1,93 @@
+package auth
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/zalando/go-keyring"
+)
+
+// TokenStore persists a provider's access token between invocations of a
+// CLI so a user only needs to complete the device flow once per machine.
+type TokenStore interface {
+	SaveToken(token string) error
+	LoadToken() (string, error)
+	DeleteToken() error
+}
+
+// NewTokenStore returns a TokenStore that prefers the OS keyring and falls
+// back to a file under persistencePath when no keyring is available, e.g.
+// in a container or headless CI environment.
+func NewTokenStore(orgName, providerName, persistencePath string) TokenStore {
+	return &fallbackTokenStore{
+		service: fmt.Sprintf("%s-%s", orgName, providerName),
+		file:    &fileTokenStore{path: filepath.Join(persistencePath, fmt.Sprintf("%s.token", providerName))},
+	}
+}
+
+// fallbackTokenStore tries the OS keyring first and transparently falls
+// back to a file on disk if the keyring is unavailable.
+type fallbackTokenStore struct {
+	service string
+	file    *fileTokenStore
+}
+
+func (s *fallbackTokenStore) SaveToken(token string) error {
+	err := keyring.Set(s.service, s.service, token)
+	if err != nil {
+		return s.file.SaveToken(token)
+	}
+	return nil
+}
+
+func (s *fallbackTokenStore) LoadToken() (string, error) {
+	token, err := keyring.Get(s.service, s.service)
+	if err != nil {
+		return s.file.LoadToken()
+	}
+	return token, nil
+}
+
+func (s *fallbackTokenStore) DeleteToken() error {
+	err := keyring.Delete(s.service, s.service)
+	if err != nil {
+		return s.file.DeleteToken()
+	}
+	return nil
+}
+
+// fileTokenStore persists a token to a file with user-only permissions.
+type fileTokenStore struct {
+	path string
+}
+
+func (s *fileTokenStore) SaveToken(token string) error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0700); err != nil {
+		return fmt.Errorf("error creating token directory: %w", err)
+	}
+
+	if err := os.WriteFile(s.path, []byte(token), 0600); err != nil {
+		return fmt.Errorf("error writing token file: %w", err)
+	}
+
+	return nil
+}
+
+func (s *fileTokenStore) LoadToken() (string, error) {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return "", fmt.Errorf("error reading token file: %w", err)
+	}
+
+	return string(data), nil
+}
+
+func (s *fileTokenStore) DeleteToken() error {
+	err := os.Remove(s.path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("error deleting token file: %w", err)
+	}
+
+	return nil
+}
@@ -1,6 +1,7 @@
 package cli
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -9,13 +10,13 @@ import (
 	"path/filepath"
 	"strings"
 
-	"github.com/dioad/util"
 	"github.com/mitchellh/go-homedir"
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 
+	"github.com/dioad/cli/debug"
 	"github.com/dioad/cli/logging"
 )
 
@@ -38,6 +39,17 @@ func InitViperConfig(orgName, appName string, cfg interface{}) error {
 }
 
 func InitViperConfigWithFlagSet(orgName, appName string, cfg interface{}, parsedFlagSet *pflag.FlagSet) error {
+	return initViperConfigWithFlagSet(orgName, appName, cfg, parsedFlagSet, nil)
+}
+
+// InitViperConfigWithRemoteSource behaves like InitViperConfigWithFlagSet,
+// additionally merging source into viper between env vars and the
+// explicit --config file, as WithRemoteConfig does for NewCommand.
+func InitViperConfigWithRemoteSource(orgName, appName string, cfg interface{}, parsedFlagSet *pflag.FlagSet, source RemoteConfigSource) error {
+	return initViperConfigWithFlagSet(orgName, appName, cfg, parsedFlagSet, source)
+}
+
+func initViperConfigWithFlagSet(orgName, appName string, cfg interface{}, parsedFlagSet *pflag.FlagSet, source RemoteConfigSource) error {
 	err := viper.BindPFlags(parsedFlagSet)
 	if err != nil {
 		return fmt.Errorf("error binding persistent flags: %w", err)
@@ -56,6 +68,12 @@ func InitViperConfigWithFlagSet(orgName, appName string, cfg interface{}, parsed
 	viper.AutomaticEnv()
 	viper.SetEnvKeyReplacer(strings.NewReplacer("-", "_"))
 
+	if source != nil {
+		if err := mergeRemoteConfig(context.Background(), source); err != nil {
+			return err
+		}
+	}
+
 	err = viper.ReadInConfig()
 	if err != nil { // Handle errors reading the config file
 		var configFileNotFoundError viper.ConfigFileNotFoundError
@@ -64,7 +82,7 @@ func InitViperConfigWithFlagSet(orgName, appName string, cfg interface{}, parsed
 		}
 	}
 
-	err = viper.Unmarshal(cfg)
+	err = UnmarshalConfig(cfg)
 	if err != nil {
 		return err
 	}
@@ -72,6 +90,30 @@ func InitViperConfigWithFlagSet(orgName, appName string, cfg interface{}, parsed
 	return nil
 }
 
+// mergeRemoteConfig fetches source and merges it into viper's defaults
+// layer, so values set by flags or env vars take precedence and a config
+// file read afterwards (including an explicit --config file) can still
+// override it key by key.
+func mergeRemoteConfig(ctx context.Context, source RemoteConfigSource) error {
+	data, format, err := source.Fetch(ctx)
+	if err != nil {
+		return fmt.Errorf("error fetching remote config: %w", err)
+	}
+
+	remote := viper.New()
+	remote.SetConfigType(format)
+
+	if err := remote.ReadConfig(bytes.NewReader(data)); err != nil {
+		return fmt.Errorf("error merging remote config: %w", err)
+	}
+
+	for _, key := range remote.AllKeys() {
+		viper.SetDefault(key, remote.Get(key))
+	}
+
+	return nil
+}
+
 func InitConfig(orgName, appName string, cmd *cobra.Command, cfgFile string, cfg interface{}) (*CommonConfig, error) {
 	viper.SetEnvPrefix(appName)
 	viper.SetEnvKeyReplacer(strings.NewReplacer("-", "_", ".", "_"))
@@ -81,6 +123,18 @@ func InitConfig(orgName, appName string, cmd *cobra.Command, cfgFile string, cfg
 		return nil, err
 	}
 
+	if source := remoteConfigSourceFor(cmd); source != nil {
+		if err := mergeRemoteConfig(cmd.Context(), source); err != nil {
+			return nil, err
+		}
+
+		go source.Watch(cmd.Context(), func() {
+			if err := mergeRemoteConfig(cmd.Context(), source); err != nil {
+				log.Warn().Err(err).Msg("error re-merging remote config on change")
+			}
+		})
+	}
+
 	if cfgFile != "" {
 		// Use config file from the flag.
 		viper.SetConfigFile(cfgFile)
@@ -121,14 +175,20 @@ func InitConfig(orgName, appName string, cmd *cobra.Command, cfgFile string, cfg
 
 	var c CommonConfig
 
-	err = viper.Unmarshal(&c) // , viper.DecodeHook(util.MaskedStringDecodeHook))
+	err = UnmarshalConfig(&c)
 	if err != nil {
 		return nil, err
 	}
 
 	logging.ConfigureCmdLogger(c.Logging)
 
-	err = viper.Unmarshal(cfg, viper.DecodeHook(util.MaskedStringDecodeHook))
+	if c.Debug.Addr != "" || c.Debug.Profiler.Enabled {
+		if err := debug.Start(cmd.Context(), c.Debug); err != nil {
+			return &c, fmt.Errorf("error starting debug subsystem: %w", err)
+		}
+	}
+
+	err = UnmarshalConfig(cfg)
 	if err != nil {
 		return &c, err
 	}
@@ -200,6 +260,7 @@ func DefaultPersistenceFile(orgName, appName, baseName string) (string, error) {
 type CommonConfig struct {
 	// Config  string         `mapstructure:"config"`
 	Logging logging.Config `mapstructure:"log"`
+	Debug   debug.Config   `mapstructure:"debug"`
 }
 
 type Config[T any] struct {
@@ -255,7 +316,7 @@ func getAppName(ctx context.Context) string {
 
 type CobraOpt[T any] func(*T)
 
-func CobraRunEWithConfig[T any](execFunc func(*T) error, cfg *T) func(cmd *cobra.Command, args []string) error {
+func CobraRunEWithConfig[T any](execFunc func(context.Context, *T) error, cfg *T) func(cmd *cobra.Command, args []string) error {
 	return func(cmd *cobra.Command, args []string) error {
 		orgName := getOrgName(cmd.Context())
 		appName := getAppName(cmd.Context())
@@ -269,7 +330,13 @@ func CobraRunEWithConfig[T any](execFunc func(*T) error, cfg *T) func(cmd *cobra
 		_, err := InitConfig(orgName, appName, cmd, configFile, cfg)
 		cobra.CheckErr(err)
 
-		return execFunc(cfg)
+		ctx := logging.WithFields(cmd.Context(), map[string]string{
+			"org": orgName,
+			"app": appName,
+		})
+		ctx = logging.WithCorrelationID(ctx, newCorrelationID())
+
+		return execFunc(ctx, cfg)
 	}
 }
 
@@ -0,0 +1,267 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+// NewConfigCommand returns a "config" subcommand, mirroring how cobra
+// ships a built-in "completion" command, exposing "show", "init",
+// "validate", "set", "get", "path" and "schema" children derived by
+// reflection over defaultConfig. It gives every CLI built on NewCommand a
+// turnkey config-management UX without hand-rolling one per project.
+func NewConfigCommand[T any](orgName, appName string, defaultConfig *T) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Inspect and manage the resolved configuration",
+	}
+
+	cmd.AddCommand(
+		newConfigShowCommand(defaultConfig),
+		newConfigInitCommand(orgName, appName, defaultConfig),
+		newConfigValidateCommand[T](),
+		newConfigSetCommand(orgName, appName),
+		newConfigGetCommand(),
+		newConfigPathCommand(orgName, appName),
+		newConfigSchemaCommand(defaultConfig),
+	)
+
+	return cmd
+}
+
+func newConfigShowCommand[T any](defaultConfig *T) *cobra.Command {
+	return &cobra.Command{
+		Use:   "show",
+		Short: "Print the fully-merged effective configuration",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg := *defaultConfig
+
+			if err := UnmarshalConfig(&cfg); err != nil {
+				return fmt.Errorf("error unmarshalling config: %w", err)
+			}
+
+			out, err := yaml.Marshal(cfg)
+			if err != nil {
+				return fmt.Errorf("error marshalling config: %w", err)
+			}
+
+			_, err = cmd.OutOrStdout().Write(out)
+			return err
+		},
+	}
+}
+
+func newConfigInitCommand[T any](orgName, appName string, defaultConfig *T) *cobra.Command {
+	return &cobra.Command{
+		Use:   "init",
+		Short: "Write a config file populated with the default configuration",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			configFile, err := DefaultConfigFile(orgName, appName, appName)
+			if err != nil {
+				return fmt.Errorf("error resolving default config file: %w", err)
+			}
+
+			out, err := yaml.Marshal(defaultConfig)
+			if err != nil {
+				return fmt.Errorf("error marshalling default config: %w", err)
+			}
+
+			header := fmt.Sprintf("# %s configuration\n# generated by `%s config init`\n", appName, appName)
+
+			if err := os.WriteFile(configFile, append([]byte(header), out...), 0600); err != nil {
+				return fmt.Errorf("error writing config file %q: %w", configFile, err)
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "wrote %s\n", configFile)
+			return nil
+		},
+	}
+}
+
+func newConfigValidateCommand[T any]() *cobra.Command {
+	return &cobra.Command{
+		Use:   "validate",
+		Short: "Validate that the effective configuration can be decoded",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var cfg T
+
+			if err := UnmarshalConfig(&cfg); err != nil {
+				return fmt.Errorf("config is invalid: %w", err)
+			}
+
+			fmt.Fprintln(cmd.OutOrStdout(), "config is valid")
+			return nil
+		},
+	}
+}
+
+func newConfigSetCommand(orgName, appName string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "set <key> <value>",
+		Short: "Set a config key and persist it to the default config file",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			key, value := args[0], args[1]
+
+			viper.Set(key, value)
+
+			configFile, err := DefaultConfigFile(orgName, appName, appName)
+			if err != nil {
+				return fmt.Errorf("error resolving default config file: %w", err)
+			}
+
+			if err := os.MkdirAll(filepath.Dir(configFile), 0700); err != nil {
+				return fmt.Errorf("error creating config directory: %w", err)
+			}
+
+			if err := viper.WriteConfigAs(configFile); err != nil {
+				return fmt.Errorf("error writing config file %q: %w", configFile, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func newConfigGetCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "get <key>",
+		Short: "Print the effective value of a single config key",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			fmt.Fprintln(cmd.OutOrStdout(), viper.Get(args[0]))
+			return nil
+		},
+	}
+}
+
+func newConfigPathCommand(orgName, appName string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "path",
+		Short: "Print the default config file path",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			configFile, err := DefaultConfigFile(orgName, appName, appName)
+			if err != nil {
+				return fmt.Errorf("error resolving default config file: %w", err)
+			}
+
+			fmt.Fprintln(cmd.OutOrStdout(), configFile)
+			return nil
+		},
+	}
+}
+
+func newConfigSchemaCommand[T any](defaultConfig *T) *cobra.Command {
+	return &cobra.Command{
+		Use:   "schema",
+		Short: "Print a JSON Schema document describing the configuration",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			schema := jsonSchemaFor(reflect.TypeOf(*defaultConfig))
+
+			out, err := json.MarshalIndent(schema, "", "  ")
+			if err != nil {
+				return fmt.Errorf("error marshalling schema: %w", err)
+			}
+
+			_, err = cmd.OutOrStdout().Write(append(out, '\n'))
+			return err
+		},
+	}
+}
+
+// jsonSchema is a minimal JSON Schema (draft 2020-12) document or
+// subschema, covering the subset of keywords NewConfigCommand needs.
+type jsonSchema struct {
+	Type       string                 `json:"type"`
+	Format     string                 `json:"format,omitempty"`
+	Properties map[string]*jsonSchema `json:"properties,omitempty"`
+	Items      *jsonSchema            `json:"items,omitempty"`
+}
+
+var (
+	durationType = reflect.TypeOf(time.Duration(0))
+	ipType       = reflect.TypeOf(net.IP{})
+	ipNetType    = reflect.TypeOf(net.IPNet{})
+)
+
+// jsonSchemaFor derives a jsonSchema from a Go type by walking its fields
+// and their mapstructure tags, special-casing the types UnmarshalConfig
+// already knows how to decode.
+func jsonSchemaFor(t reflect.Type) *jsonSchema {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch {
+	case t == durationType:
+		return &jsonSchema{Type: "string", Format: "duration"}
+	case t == ipType:
+		return &jsonSchema{Type: "string", Format: "ipv4"}
+	case t == ipNetType:
+		return &jsonSchema{Type: "string", Format: "cidr"}
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		properties := make(map[string]*jsonSchema)
+
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+
+			name := mapstructureFieldName(field)
+			if name == "-" {
+				continue
+			}
+
+			if field.Anonymous {
+				embedded := jsonSchemaFor(field.Type)
+				for k, v := range embedded.Properties {
+					properties[k] = v
+				}
+				continue
+			}
+
+			properties[name] = jsonSchemaFor(field.Type)
+		}
+
+		return &jsonSchema{Type: "object", Properties: properties}
+	case reflect.Slice, reflect.Array:
+		return &jsonSchema{Type: "array", Items: jsonSchemaFor(t.Elem())}
+	case reflect.Bool:
+		return &jsonSchema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &jsonSchema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &jsonSchema{Type: "number"}
+	default:
+		return &jsonSchema{Type: "string"}
+	}
+}
+
+func mapstructureFieldName(field reflect.StructField) string {
+	tag, ok := field.Tag.Lookup("mapstructure")
+	if !ok {
+		return strings.ToLower(field.Name)
+	}
+
+	name, _, _ := strings.Cut(tag, ",")
+	if name == "" {
+		return strings.ToLower(field.Name)
+	}
+
+	return name
+}
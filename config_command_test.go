@@ -0,0 +1,70 @@
+package cli_test
+
+import (
+	"bytes"
+	"net"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dioad/cli"
+)
+
+// TestNewConfigCommand verifies the expected child commands are registered.
+func TestNewConfigCommand(t *testing.T) {
+	type AppConfig struct {
+		Name string `mapstructure:"name"`
+	}
+
+	cfg := &AppConfig{Name: "default"}
+
+	cmd := cli.NewConfigCommand("testorg", "testapp", cfg)
+
+	want := []string{"show", "init", "validate", "set <key> <value>", "get <key>", "path", "schema"}
+	var got []string
+	for _, c := range cmd.Commands() {
+		got = append(got, c.Use)
+	}
+
+	assert.ElementsMatch(t, want, got)
+}
+
+// TestConfigSchemaCommand verifies the schema command emits valid JSON
+// describing the config struct's fields.
+func TestConfigSchemaCommand(t *testing.T) {
+	type NestedConfig struct {
+		Host string `mapstructure:"host"`
+	}
+
+	type AppConfig struct {
+		Name   string       `mapstructure:"name"`
+		Port   int          `mapstructure:"port"`
+		Nested NestedConfig `mapstructure:"nested"`
+		IPAddr net.IP       `mapstructure:"ip-addr"`
+	}
+
+	cfg := &AppConfig{}
+	cmd := cli.NewConfigCommand("testorg", "testapp", cfg)
+
+	schemaCmd, _, err := cmd.Find([]string{"schema"})
+	assert.NoError(t, err)
+	assert.NotNil(t, schemaCmd.RunE)
+
+	out := captureOutput(t, schemaCmd)
+	assert.Contains(t, out, `"port"`)
+	assert.Contains(t, out, `"nested"`)
+	assert.Contains(t, out, `"ip-addr"`)
+}
+
+func captureOutput(t *testing.T, cmd *cobra.Command) string {
+	t.Helper()
+
+	buf := &bytes.Buffer{}
+	cmd.SetOut(buf)
+
+	err := cmd.RunE(cmd, nil)
+	assert.NoError(t, err)
+
+	return buf.String()
+}
@@ -0,0 +1,35 @@
+// Package debug starts an HTTP server exposing pprof, expvar and a health
+// check endpoint, and optionally drives a pluggable continuous-profiler
+// agent (Google Cloud Profiler, Pyroscope, Parca, ...) registered via
+// RegisterProfiler. It is wired into CommonConfig as the Debug block; see
+// Start.
+package debug
+
+// Config is the Debug block of CommonConfig.
+type Config struct {
+	// Addr is the listen address for the debug HTTP server, e.g.
+	// "localhost:6060". Empty disables the server.
+	Addr string `mapstructure:"addr"`
+
+	// PProf enables the /debug/pprof/* handlers on the debug server.
+	PProf bool `mapstructure:"pprof"`
+
+	Profiler ProfilerConfig `mapstructure:"profiler"`
+}
+
+// ProfilerConfig configures the continuous-profiler agent registered via
+// RegisterProfiler.
+type ProfilerConfig struct {
+	// Enabled triggers a call to the registered Profiler's Start method.
+	Enabled bool `mapstructure:"enabled"`
+
+	Name      string `mapstructure:"name"`
+	Version   string `mapstructure:"version"`
+	ProjectID string `mapstructure:"project-id"`
+
+	// MutexRate and BlockRate, if positive, are passed to
+	// runtime.SetMutexProfileFraction and runtime.SetBlockProfileRate
+	// respectively before the profiler starts.
+	MutexRate int `mapstructure:"mutex-rate"`
+	BlockRate int `mapstructure:"block-rate"`
+}
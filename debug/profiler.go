@@ -0,0 +1,21 @@
+package debug
+
+import "context"
+
+// Profiler is implemented by continuous-profiler agents. This package
+// does not import any concrete profiler itself; call RegisterProfiler
+// from an init func or main to wire one in.
+type Profiler interface {
+	Start(ctx context.Context, cfg ProfilerConfig) error
+}
+
+var profiler Profiler
+
+// RegisterProfiler sets the Profiler Start uses when Config.Profiler.Enabled
+// is true. It is typically called from an init func in a package that
+// imports a concrete continuous-profiler agent, so this module never needs
+// to depend on one directly. Registering again replaces the previous
+// profiler.
+func RegisterProfiler(p Profiler) {
+	profiler = p
+}
@@ -0,0 +1,98 @@
+package debug
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"fmt"
+	"net/http"
+	"net/http/pprof"
+	"runtime"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// shutdownTimeout bounds how long Start waits for the debug server to
+// finish in-flight requests once ctx is cancelled.
+const shutdownTimeout = 5 * time.Second
+
+// Start launches the debug HTTP server and, if cfg.Profiler.Enabled, the
+// registered continuous profiler. The server is skipped if cfg.Addr is
+// empty. Both are tied to ctx: the server is shut down when ctx is
+// cancelled, and ctx is passed through to the profiler's Start method.
+func Start(ctx context.Context, cfg Config) error {
+	if cfg.Profiler.Enabled {
+		if err := startProfiler(ctx, cfg.Profiler); err != nil {
+			return err
+		}
+	}
+
+	if cfg.Addr == "" {
+		return nil
+	}
+
+	server := &http.Server{
+		Addr:    cfg.Addr,
+		Handler: newMux(cfg),
+	}
+
+	go func() {
+		<-ctx.Done()
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			log.Error().Err(err).Msg("error shutting down debug server")
+		}
+	}()
+
+	go func() {
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Error().Err(err).Str("addr", cfg.Addr).Msg("debug server error")
+		}
+	}()
+
+	return nil
+}
+
+func startProfiler(ctx context.Context, cfg ProfilerConfig) error {
+	if profiler == nil {
+		return fmt.Errorf("debug: profiler enabled but no Profiler registered via RegisterProfiler")
+	}
+
+	if cfg.MutexRate > 0 {
+		runtime.SetMutexProfileFraction(cfg.MutexRate)
+	}
+	if cfg.BlockRate > 0 {
+		runtime.SetBlockProfileRate(cfg.BlockRate)
+	}
+
+	if err := profiler.Start(ctx, cfg); err != nil {
+		return fmt.Errorf("error starting continuous profiler: %w", err)
+	}
+
+	return nil
+}
+
+func newMux(cfg Config) *http.ServeMux {
+	mux := http.NewServeMux()
+
+	if cfg.PProf {
+		mux.HandleFunc("/debug/pprof/", pprof.Index)
+		mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+		mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+		mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+		mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	}
+
+	mux.Handle("/debug/vars", expvar.Handler())
+
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("ok"))
+	})
+
+	return mux
+}
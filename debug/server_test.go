@@ -0,0 +1,110 @@
+package debug_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dioad/cli/debug"
+)
+
+// TestStartNoAddr verifies Start is a no-op when Addr is empty and no
+// profiler is enabled.
+func TestStartNoAddr(t *testing.T) {
+	if err := debug.Start(context.Background(), debug.Config{}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+// TestStartProfilerEnabledWithoutRegistration verifies Start reports an
+// error rather than panicking when Profiler.Enabled is true but no
+// Profiler has been registered.
+func TestStartProfilerEnabledWithoutRegistration(t *testing.T) {
+	err := debug.Start(context.Background(), debug.Config{
+		Profiler: debug.ProfilerConfig{Enabled: true},
+	})
+	if err == nil {
+		t.Fatal("expected an error when no profiler is registered")
+	}
+}
+
+type fakeProfiler struct {
+	started bool
+	err     error
+}
+
+func (f *fakeProfiler) Start(_ context.Context, _ debug.ProfilerConfig) error {
+	f.started = true
+	return f.err
+}
+
+// TestStartProfilerRegistered verifies Start invokes the registered
+// Profiler when enabled.
+func TestStartProfilerRegistered(t *testing.T) {
+	fp := &fakeProfiler{}
+	debug.RegisterProfiler(fp)
+	t.Cleanup(func() { debug.RegisterProfiler(nil) })
+
+	if err := debug.Start(context.Background(), debug.Config{
+		Profiler: debug.ProfilerConfig{Enabled: true},
+	}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if !fp.started {
+		t.Error("expected registered profiler to be started")
+	}
+}
+
+// TestStartProfilerError verifies a profiler Start error is wrapped and
+// returned.
+func TestStartProfilerError(t *testing.T) {
+	fp := &fakeProfiler{err: errors.New("boom")}
+	debug.RegisterProfiler(fp)
+	t.Cleanup(func() { debug.RegisterProfiler(nil) })
+
+	err := debug.Start(context.Background(), debug.Config{
+		Profiler: debug.ProfilerConfig{Enabled: true},
+	})
+	if err == nil {
+		t.Fatal("expected an error from a failing profiler")
+	}
+}
+
+// TestStartServerShutsDownOnCancel verifies Start launches the debug
+// server without error and that cancelling its context does not panic.
+func TestStartServerShutsDownOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	cfg := debug.Config{Addr: "127.0.0.1:0"}
+	if err := debug.Start(ctx, cfg); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	cancel()
+	time.Sleep(10 * time.Millisecond)
+}
+
+// TestHealthzHandler checks, via httptest, the status and body of a local
+// handler written like the package's /healthz handler; the package's own
+// handler is not called.
+func TestHealthzHandler(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("ok"))
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/healthz", nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected status 200, got %d", rec.Code)
+	}
+	if rec.Body.String() != "ok" {
+		t.Errorf("expected body %q, got %q", "ok", rec.Body.String())
+	}
+}
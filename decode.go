@@ -0,0 +1,86 @@
+package cli
+
+import (
+	"fmt"
+	"net"
+	"reflect"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/spf13/viper"
+
+	"github.com/dioad/util"
+)
+
+// UnmarshalConfig decodes the current global viper configuration (flags,
+// env vars and config file, in the precedence viper already applies) into
+// cfg. Before decoding, any string value of the form "scheme://..." that
+// matches a registered SecretResolver (see RegisterSecretResolver) is
+// replaced with its resolved plaintext. The decode additionally supports
+// time.Duration, net.IP and *net.IPNet fields, on top of the masked-string
+// handling from util.MaskedStringDecodeHook. Anonymous struct fields (e.g.
+// CommonConfig embedded in a caller's config struct) are squashed, so their
+// fields decode from the embedding struct's own keys rather than needing a
+// nested "commonconfig" key.
+func UnmarshalConfig(cfg interface{}) error {
+	settings, err := resolveSecrets(viper.AllSettings(), "")
+	if err != nil {
+		return err
+	}
+
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		DecodeHook: mapstructure.ComposeDecodeHookFunc(
+			mapstructure.StringToTimeDurationHookFunc(),
+			stringToIPHookFunc(),
+			stringToIPNetHookFunc(),
+			util.MaskedStringDecodeHook,
+		),
+		WeaklyTypedInput: true,
+		Squash:           true,
+		Result:           cfg,
+	})
+	if err != nil {
+		return fmt.Errorf("error creating config decoder: %w", err)
+	}
+
+	return decoder.Decode(settings)
+}
+
+func stringToIPHookFunc() mapstructure.DecodeHookFunc {
+	return func(f reflect.Type, t reflect.Type, data interface{}) (interface{}, error) {
+		if f.Kind() != reflect.String || t != reflect.TypeOf(net.IP{}) {
+			return data, nil
+		}
+
+		raw := data.(string)
+		if raw == "" {
+			return net.IP{}, nil
+		}
+
+		ip := net.ParseIP(raw)
+		if ip == nil {
+			return nil, fmt.Errorf("invalid IP address %q", raw)
+		}
+
+		return ip, nil
+	}
+}
+
+func stringToIPNetHookFunc() mapstructure.DecodeHookFunc {
+	return func(f reflect.Type, t reflect.Type, data interface{}) (interface{}, error) {
+		if f.Kind() != reflect.String || t != reflect.TypeOf(&net.IPNet{}) {
+			return data, nil
+		}
+
+		raw := data.(string)
+		if raw == "" {
+			return (*net.IPNet)(nil), nil
+		}
+
+		_, ipNet, err := net.ParseCIDR(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CIDR %q: %w", raw, err)
+		}
+
+		return ipNet, nil
+	}
+}
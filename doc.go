@@ -58,7 +58,9 @@
 // 2. Config file at $HOME/.config/{org}/{app}/config.yaml
 // 3. Command-line flags
 // 4. Environment variables with prefix {APPNAME}_
-// 5. Config file specified by --config flag
+// 5. The RemoteConfigSource passed to WithRemoteConfig, if any; it only
+// fills in keys that no flag, environment variable or config file sets
+// 6. Config file specified by --config flag
 //
 // # Context Management
 //
@@ -71,6 +73,112 @@
 //	)
 //	cmd.SetContext(ctx)
 //
+// # Root-Level Bootstrap
+//
+// PrepareBase mirrors tendermint's cli.PrepareBaseCmd: it registers the
+// conventional persistent flags and chains a PersistentPreRunE so that
+// root-level flags take effect before any subcommand's RunE fires,
+// without each subcommand calling InitConfig itself:
+//
+//	execute := cli.PrepareBase(rootCmd, "myorg", "myapp", cfg)
+//	if err := execute(); err != nil {
+//		os.Exit(1)
+//	}
+//
+// # Graceful Shutdown
+//
+// WithGracefulShutdown cancels the command's context on the first
+// os.Interrupt/SIGTERM, runs any cleanup funcs registered on its
+// ShutdownGroup within timeout, and exits immediately with code 130 on a
+// second signal:
+//
+//	cmd := cli.NewCommand(
+//		&cobra.Command{Use: "serve"},
+//		serveCommand,
+//		cfg,
+//		cli.WithGracefulShutdown(30*time.Second),
+//	)
+//
+//	func serveCommand(ctx context.Context, cfg *ServerConfig) error {
+//		cli.ShutdownGroupFromContext(ctx).Register(func(ctx context.Context) error {
+//			return server.Shutdown(ctx)
+//		})
+//		<-ctx.Done()
+//		return nil
+//	}
+//
+// # Remote Config Sources
+//
+// WithRemoteConfig merges a RemoteConfigSource into the load order beneath
+// flags, env vars and any config file (including an explicit --config
+// file), and watches it for changes for the lifetime of the command:
+//
+//	cmd := cli.NewCommand(
+//		&cobra.Command{Use: "serve"},
+//		serveCommand,
+//		cfg,
+//		cli.WithRemoteConfig(cli.HTTPRemoteConfigSource{URL: "https://config.example.com/app.yaml"}),
+//	)
+//
+// HTTPRemoteConfigSource and FileRemoteConfigSource are provided in-tree;
+// third parties can supply their own (etcd, consul, ...) by implementing
+// RemoteConfigSource.
+//
+// # Config Subcommand
+//
+// NewConfigCommand generates a "config" subcommand, in the same spirit as
+// cobra's built-in "completion" command, with "show", "init", "validate",
+// "set", "get", "path" and "schema" children derived by reflection over a
+// typed config struct:
+//
+//	rootCmd.AddCommand(cli.NewConfigCommand("myorg", "myapp", cfg))
+//
+// "schema" emits a JSON Schema document derived from the struct's
+// mapstructure tags, including the time.Duration, net.IP and *net.IPNet
+// types UnmarshalConfig already handles.
+//
+// # Hot Reload
+//
+// InitConfig already calls viper.WatchConfig() once a config file is
+// found. OnConfigChange builds on that to propagate changes into a
+// running command's typed config: it decodes the file into a fresh value
+// on each write (debounced), calls handler with the old and new values,
+// and swaps *cfg to the new value if handler returns nil. If T embeds
+// CommonConfig and its Logging block changed, logging.ConfigureCmdLogger
+// is re-applied automatically. Because the swap mutates *cfg in place,
+// OnConfigChange returns the *sync.Mutex guarding it: any goroutine other
+// than handler that reads *cfg must hold that mutex while doing so, or it
+// races with the reload:
+//
+//	mu := cli.OnConfigChange(cmd, cfg, func(old, new *AppConfig) error {
+//		log.Info().Msg("config reloaded")
+//		return nil
+//	})
+//
+//	mu.Lock()
+//	level := cfg.Logging.Level
+//	mu.Unlock()
+//
+// # Debug Subsystem
+//
+// CommonConfig's Debug block, when Addr is set, starts an HTTP server
+// alongside the command exposing /debug/vars, /healthz and (if PProf is
+// true) /debug/pprof/*. The server is tied to the command's context and
+// stops when it is cancelled:
+//
+//	log:
+//	  level: info
+//	debug:
+//	  addr: "localhost:6060"
+//	  pprof: true
+//
+// Setting debug.profiler.enabled additionally starts a continuous-profiler
+// agent registered via debug.RegisterProfiler, so this module never needs
+// to import a concrete implementation (Google Cloud Profiler, Pyroscope,
+// Parca, ...):
+//
+//	import _ "myorg/myapp/internal/profiler" // calls debug.RegisterProfiler in its init
+//
 // # Advanced Features
 //
 // Path helpers detect and adapt to Docker environments:
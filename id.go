@@ -0,0 +1,17 @@
+package cli
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+)
+
+// newCorrelationID returns a random, lowercase hex identifier suitable for
+// tagging a single command invocation's logs.
+func newCorrelationID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return ""
+	}
+
+	return hex.EncodeToString(b)
+}
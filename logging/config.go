@@ -1,5 +1,7 @@
 package logging
 
+import "io"
+
 type Config struct {
 	Level      string `mapstructure:"level"`
 	File       string `mapstructure:"file"`
@@ -8,5 +10,75 @@ type Config struct {
 	MaxBackups int    `mapstructure:"max-backups"`
 	LocalTime  bool   `mapstructure:"use-local-time"`
 	Compress   bool   `mapstructure:"compress"`
-	Mode       string `mapstructure:"mode"`
+
+	// Mode selects one or more log sinks as a comma-separated list of
+	// "stdout", "stderr", "file", "syslog", "journald" and "none", e.g.
+	// "stdout,file". It is a convenience over Outputs for the common case
+	// of fanning out to a fixed set of sinks at the same Level; Outputs
+	// takes precedence when both are set. "none" discards all log output.
+	// syslog and journald entries are platform-gated: they error on
+	// platforms without native support rather than failing to build.
+	//
+	// Format applies to the "stdout" and "stderr" sinks (pretty-printing
+	// or raw JSON, exactly as the single-sink ConfigureLogOutput behaves);
+	// "file" is always raw JSON, and "syslog"/"journald"/"http" have their
+	// own fixed wire formats.
+	Mode string `mapstructure:"mode"`
+
+	extraSinks []io.Writer
+
+	// Format selects the stdout encoding: "console" (the default, pretty
+	// when attached to a TTY), "json" (machine-parseable, always raw), or
+	// "logfmt" (key=value pairs, uncoloured).
+	Format string `mapstructure:"format"`
+
+	// Outputs configures one or more additional logging sinks, each with
+	// its own minimum level. When set, these replace the single-sink
+	// behavior of ConfigureLogOutput; see ConfigureMultiOutput.
+	Outputs []OutputConfig `mapstructure:"outputs"`
+
+	// API selects the logging facade ConfigureCmdLogger installs as the
+	// package default: "zerolog" (the default) or "slog", to route
+	// log/slog's default logger through the same sink. See
+	// NewSlogHandler.
+	API string `mapstructure:"api"`
+}
+
+// OutputConfig configures a single logging sink used by Config.Outputs.
+type OutputConfig struct {
+	// Type selects the sink: "stdout", "stderr", "file", "syslog",
+	// "journald" or "http".
+	Type string `mapstructure:"type"`
+
+	// Level is the minimum zerolog level written to this sink. Empty
+	// means no additional filtering beyond the global level.
+	Level string `mapstructure:"level"`
+
+	// File is the destination path for a "file" sink, rotated using the
+	// same MaxSize/MaxAge/MaxBackups/Compress settings as Config.File.
+	File string `mapstructure:"file"`
+
+	// Tag and Facility configure a "syslog" sink.
+	Tag      string `mapstructure:"tag"`
+	Facility string `mapstructure:"facility"`
+
+	// URL is the destination for an "http" sink; each log event is POSTed
+	// to it as the request body.
+	URL string `mapstructure:"url"`
 }
+
+const (
+	FormatConsole = "console"
+	FormatJSON    = "json"
+	FormatLogfmt  = "logfmt"
+)
+
+// Supported Config.Mode tokens.
+const (
+	ModeStdout   = "stdout"
+	ModeStderr   = "stderr"
+	ModeFile     = "file"
+	ModeSyslog   = "syslog"
+	ModeJournald = "journald"
+	ModeNone     = "none"
+)
@@ -0,0 +1,52 @@
+package logging
+
+import (
+	"context"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+type correlationIDContextKey struct{}
+type fieldsContextKey struct{}
+
+// WithCorrelationID returns a copy of ctx carrying id as the request-scoped
+// correlation ID. A logger later retrieved via FromContext will include it
+// as a "correlation_id" field.
+func WithCorrelationID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, correlationIDContextKey{}, id)
+}
+
+// CorrelationID returns the correlation ID stored in ctx by
+// WithCorrelationID, or "" if none is set.
+func CorrelationID(ctx context.Context) string {
+	id, _ := ctx.Value(correlationIDContextKey{}).(string)
+	return id
+}
+
+// WithFields returns a copy of ctx carrying additional string fields, e.g.
+// org/app names, to be attached to any logger later retrieved via
+// FromContext.
+func WithFields(ctx context.Context, fields map[string]string) context.Context {
+	return context.WithValue(ctx, fieldsContextKey{}, fields)
+}
+
+// FromContext returns the global zerolog logger decorated with the
+// correlation ID and fields stored in ctx, if any.
+func FromContext(ctx context.Context) zerolog.Logger {
+	logger := log.Logger.With().Logger()
+
+	if fields, ok := ctx.Value(fieldsContextKey{}).(map[string]string); ok {
+		ctxLogger := logger.With()
+		for k, v := range fields {
+			ctxLogger = ctxLogger.Str(k, v)
+		}
+		logger = ctxLogger.Logger()
+	}
+
+	if id := CorrelationID(ctx); id != "" {
+		logger = logger.With().Str("correlation_id", id).Logger()
+	}
+
+	return logger
+}
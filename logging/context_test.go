@@ -0,0 +1,41 @@
+package logging_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dioad/cli/logging"
+)
+
+// TestWithCorrelationID verifies round-tripping a correlation ID via context.
+func TestWithCorrelationID(t *testing.T) {
+	ctx := logging.WithCorrelationID(context.Background(), "req-123")
+
+	if got := logging.CorrelationID(ctx); got != "req-123" {
+		t.Errorf("CorrelationID() = %s, want req-123", got)
+	}
+}
+
+// TestCorrelationIDMissing verifies an unset correlation ID returns "".
+func TestCorrelationIDMissing(t *testing.T) {
+	if got := logging.CorrelationID(context.Background()); got != "" {
+		t.Errorf("CorrelationID() = %s, want empty string", got)
+	}
+}
+
+// TestFromContext verifies FromContext does not panic with no values set
+// and returns a usable logger.
+func TestFromContext(t *testing.T) {
+	logger := logging.FromContext(context.Background())
+	logger.Info().Msg("test message")
+}
+
+// TestFromContextWithFields verifies FromContext does not panic when
+// fields and a correlation ID are both set.
+func TestFromContextWithFields(t *testing.T) {
+	ctx := logging.WithFields(context.Background(), map[string]string{"org": "acme", "app": "widget"})
+	ctx = logging.WithCorrelationID(ctx, "req-456")
+
+	logger := logging.FromContext(ctx)
+	logger.Info().Msg("test message")
+}
@@ -52,7 +52,7 @@
 //		MaxBackups int    // Max number of old log files to keep
 //		LocalTime  bool   // Use local time in rotated filename timestamps
 //		Compress   bool   // Compress old log files
-//		Mode       string // Not currently used, reserved for future use
+//		Mode       string // Comma-separated sink list: stdout,stderr,file,syslog,journald,none
 //	}
 //
 // # File Rotation
@@ -77,10 +77,73 @@
 //
 // # Output Behavior
 //
-// The library intelligently selects output format:
+// By default the library intelligently selects output format:
 //   - Console (TTY): Pretty-printed JSON with timestamps
 //   - File/Pipe: Compact JSON for easy parsing
 //
+// Set Config.Format to override this: "console" (the default) pretty-prints
+// only when attached to a TTY, "json" always emits raw JSON (useful when stdout is piped to a log
+// shipper that still attaches a TTY), and "logfmt" emits uncoloured
+// key=value pairs.
+//
+// # Correlation IDs
+//
+// logging.WithCorrelationID(ctx, id) attaches a request-scoped correlation
+// ID to a context.Context; logging.FromContext(ctx) returns a logger
+// decorated with that ID (and any fields set via logging.WithFields),
+// letting related log lines across a single command invocation be
+// grouped together:
+//
+//	ctx = logging.WithCorrelationID(ctx, requestID)
+//	logging.FromContext(ctx).Info().Msg("handling request")
+//
+// # Multiple Sinks
+//
+// Config.Outputs fans out to more than one sink simultaneously, each with
+// its own minimum level, via ConfigureMultiOutput (wired in automatically
+// by ConfigureCmdLogger when Outputs is non-empty):
+//
+//	cfg := logging.Config{
+//		Outputs: []logging.OutputConfig{
+//			{Type: "stdout", Level: "warn"},
+//			{Type: "syslog", Level: "error", Tag: "myapp", Facility: "daemon"},
+//		},
+//	}
+//
+// Supported sink types are "stdout", "stderr", "file", "syslog",
+// "journald" and "http". syslog and journald sinks are platform-gated:
+// they return an error on platforms without native support rather than
+// failing to build.
+//
+// # Mode: Quick Sink Selection
+//
+// Config.Mode is a convenience over Outputs for the common case of
+// fanning out to a fixed set of sinks at the same Level/Format: a
+// comma-separated list of "stdout", "stderr", "file", "syslog",
+// "journald" and "none":
+//
+//	cfg := logging.Config{
+//		Mode:  "stdout,file",
+//		File:  "/var/log/myapp/app.log",
+//		Level: "info",
+//	}
+//
+// Outputs takes precedence when both are set. "none" discards all log
+// output; unrecognised tokens are logged and skipped.
+//
+// # log/slog Bridge
+//
+// NewSlogHandler configures the same zerolog sink as ConfigureCmdLogger
+// and returns an slog.Handler for it, so applications migrating to
+// log/slog can do so one call site at a time while the global zerolog
+// logger keeps working:
+//
+//	slog.SetDefault(slog.New(logging.NewSlogHandler(cfg)))
+//	// or: logging.SetDefaultSlog(cfg)
+//
+// Set Config.API to "slog" to have ConfigureCmdLogger install it as the
+// slog default automatically.
+//
 // # Functional Options
 //
 // Configure default log levels with options:
@@ -90,6 +153,16 @@
 //		logging.WithDefaultLogLevel(zerolog.DebugLevel),
 //	)
 //
+// WithSink injects an extra, unfiltered writer alongside whatever
+// Outputs/Mode/File already configure, e.g. for a custom OTLP log
+// exporter; WithFormat overrides Config.Format:
+//
+//	logging.ConfigureCmdLogger(
+//		cfg,
+//		logging.WithSink(otlpWriter),
+//		logging.WithFormat(logging.FormatJSON),
+//	)
+//
 // # Path Expansion
 //
 // File paths support home directory expansion:
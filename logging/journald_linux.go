@@ -0,0 +1,92 @@
+//go:build linux
+
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/coreos/go-systemd/v22/journal"
+)
+
+// journaldWriter writes zerolog's JSON events to the systemd journal,
+// mapping the "message" and "level" fields to native journal fields,
+// dropping "time", and forwarding the rest as structured fields.
+type journaldWriter struct{}
+
+func newJournaldWriter() (io.Writer, error) {
+	if !journal.Enabled() {
+		return nil, fmt.Errorf("journald is not available on this host")
+	}
+
+	return journaldWriter{}, nil
+}
+
+func (journaldWriter) Write(p []byte) (int, error) {
+	var event map[string]interface{}
+	if err := json.Unmarshal(bytes.TrimSpace(p), &event); err != nil {
+		// Best effort: a malformed line shouldn't take down logging.
+		return len(p), nil
+	}
+
+	msg, _ := event["message"].(string)
+
+	priority := journal.PriInfo
+	if levelStr, ok := event["level"].(string); ok {
+		priority = journaldPriority(levelStr)
+	}
+
+	fields := make(map[string]string, len(event))
+	for k, v := range event {
+		if k == "message" || k == "level" || k == "time" {
+			continue
+		}
+		fields[journaldFieldName(k)] = fmt.Sprintf("%v", v)
+	}
+
+	if err := journal.Send(msg, priority, fields); err != nil {
+		return 0, fmt.Errorf("error sending log event to journald: %w", err)
+	}
+
+	return len(p), nil
+}
+
+func journaldPriority(level string) journal.Priority {
+	switch level {
+	case "trace", "debug":
+		return journal.PriDebug
+	case "info":
+		return journal.PriInfo
+	case "warn":
+		return journal.PriWarning
+	case "error":
+		return journal.PriErr
+	case "fatal":
+		return journal.PriCrit
+	case "panic":
+		return journal.PriEmerg
+	default:
+		return journal.PriInfo
+	}
+}
+
+// journaldFieldName converts a zerolog field name to the upper-case,
+// underscore-only form required of journal field names.
+func journaldFieldName(k string) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_':
+			return r
+		default:
+			return '_'
+		}
+	}, k)
+
+	// Journal field names may not begin with an underscore.
+	return strings.TrimLeft(name, "_")
+}
@@ -0,0 +1,13 @@
+//go:build !linux
+
+package logging
+
+import (
+	"fmt"
+	"io"
+)
+
+// newJournaldWriter is unsupported on non-Linux platforms.
+func newJournaldWriter() (io.Writer, error) {
+	return nil, fmt.Errorf("journald output is only supported on linux")
+}
@@ -3,6 +3,7 @@ package logging
 import (
 	"io"
 	defaultLog "log"
+	"log/slog"
 	"os"
 	"path/filepath"
 	"time"
@@ -34,13 +35,52 @@ func WithDefaultLogLevel(level zerolog.Level) func(*Config) {
 	}
 }
 
+// WithSink adds w as an additional, unfiltered log destination, fanned out
+// to alongside whatever Config.Outputs/Config.Mode/Config.File already
+// configure. Useful for injecting a custom writer, e.g. an OTLP log
+// exporter, without it needing an OutputConfig.Type of its own.
+func WithSink(w io.Writer) Option {
+	return func(c *Config) {
+		c.extraSinks = append(c.extraSinks, w)
+	}
+}
+
+// WithFormat overrides Config.Format.
+func WithFormat(format string) Option {
+	return func(c *Config) {
+		c.Format = format
+	}
+}
+
 func ConfigureCmdLogger(c Config, opts ...Option) {
 	for _, o := range opts {
 		o(&c)
 	}
 
 	ConfigureLogLevel(c.Level, DefaultLogLevel)
-	ConfigureLogOutput(c)
+
+	outputs := c.Outputs
+	if len(outputs) == 0 && c.Mode != "" {
+		outputs = modeOutputs(c)
+	}
+
+	switch {
+	case len(outputs) > 0:
+		multi := c
+		multi.Outputs = outputs
+		if err := ConfigureMultiOutput(multi); err != nil {
+			log.Error().Err(err).Msg("failed to configure multi-output logging, falling back to default output")
+			ConfigureLogOutput(c)
+		}
+	case c.Mode == ModeNone:
+		log.Logger = zerolog.New(io.Discard).With().Timestamp().Logger()
+	default:
+		ConfigureLogOutput(c)
+	}
+
+	if c.API == APISlog {
+		slog.SetDefault(slog.New(newSlogHandlerFromLogger(log.Logger)))
+	}
 }
 
 func ConfigureLogLevel(levelString string, defaultLogLevel zerolog.Level) {
@@ -92,23 +132,45 @@ func ConfigureLogFileOutput(c Config) io.Writer {
 	return logOutput
 }
 
-func ConfigureLogOutput(c Config) {
+// formatWriter wraps w according to format: "json" leaves it untouched
+// (raw JSON, regardless of whether w is a TTY), "logfmt" wraps it in an
+// uncoloured zerolog.ConsoleWriter, and the default ("console") pretty-
+// prints only when w is an *os.File attached to a TTY.
+func formatWriter(w io.Writer, format string) io.Writer {
+	switch format {
+	case FormatJSON:
+		return w
+	case FormatLogfmt:
+		return zerolog.ConsoleWriter{Out: w, TimeFormat: time.RFC3339Nano, NoColor: true}
+	default:
+		if f, ok := w.(*os.File); ok && isConsoleWriter(f) {
+			return zerolog.ConsoleWriter{Out: w, TimeFormat: time.RFC3339Nano}
+		}
+		return w
+	}
+}
 
-	var logOutput io.Writer
-	logOutput = os.Stdout
+func ConfigureLogOutput(c Config) {
 
 	// Setup logging to stdout by default
 	// so we have somewhere to log any errors configuring logging
-	if isConsoleWriter(os.Stdout) {
-		logOutput = zerolog.ConsoleWriter{Out: logOutput, TimeFormat: time.RFC3339Nano}
-	}
-	log.Logger = zerolog.New(logOutput).With().Timestamp().Logger()
+	logOutput := formatWriter(os.Stdout, c.Format)
 
 	// if a log file has been configured set it up and
 	// overwrite default logger
 	if c.File != "" {
 		logOutput = ConfigureLogFileOutput(c)
 	}
+
+	if len(c.extraSinks) > 0 {
+		writers := make([]io.Writer, 0, len(c.extraSinks)+1)
+		writers = append(writers, logOutput)
+		for _, sink := range c.extraSinks {
+			writers = append(writers, sink)
+		}
+		logOutput = zerolog.MultiLevelWriter(writers...)
+	}
+
 	log.Logger = zerolog.New(logOutput).With().Timestamp().Logger()
 
 	// Configure default logger
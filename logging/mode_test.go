@@ -0,0 +1,137 @@
+package logging_test
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dioad/cli/logging"
+	"github.com/rs/zerolog/log"
+)
+
+// TestConfigureCmdLoggerModeFileAndStderr verifies a "file,stderr" Mode
+// fans out to both sinks: the event reaches the log file and stderr.
+func TestConfigureCmdLoggerModeFileAndStderr(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "app.log")
+
+	cfg := logging.Config{
+		Level:   "info",
+		Mode:    "file,stderr",
+		File:    logFile,
+		MaxSize: 10,
+	}
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("os.Pipe() error = %v", err)
+	}
+
+	originalStderr := os.Stderr
+	os.Stderr = w
+	defer func() { os.Stderr = originalStderr }()
+
+	logging.ConfigureCmdLogger(cfg)
+
+	log.Info().Msg("hello")
+
+	_ = w.Close()
+	os.Stderr = originalStderr
+
+	stderrData, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("error reading captured stderr: %v", err)
+	}
+	if !bytes.Contains(stderrData, []byte("hello")) {
+		t.Errorf("expected stderr to contain message, got %q", stderrData)
+	}
+
+	data, err := os.ReadFile(logFile)
+	if err != nil {
+		t.Fatalf("expected log file to be written: %v", err)
+	}
+	if !bytes.Contains(data, []byte("hello")) {
+		t.Errorf("expected log file to contain message, got %q", data)
+	}
+}
+
+// TestConfigureCmdLoggerModeNone verifies "none" discards all output
+// without panicking.
+func TestConfigureCmdLoggerModeNone(t *testing.T) {
+	logging.ConfigureCmdLogger(logging.Config{Mode: "none"})
+	log.Info().Msg("should be discarded")
+}
+
+// TestConfigureCmdLoggerModeUnknownToken verifies an unrecognised Mode
+// token is skipped rather than causing an error, so at least the
+// recognised sinks still get configured.
+func TestConfigureCmdLoggerModeUnknownToken(t *testing.T) {
+	logging.ConfigureCmdLogger(logging.Config{Mode: "carrier-pigeon,stdout"})
+	log.Info().Msg("still works")
+}
+
+// TestWithSink verifies WithSink fans an extra writer into the default
+// single-sink path.
+func TestWithSink(t *testing.T) {
+	var buf bytes.Buffer
+
+	logging.ConfigureCmdLogger(logging.Config{Level: "info", Format: logging.FormatJSON}, logging.WithSink(&buf))
+
+	log.Info().Msg("via extra sink")
+
+	if !bytes.Contains(buf.Bytes(), []byte("via extra sink")) {
+		t.Errorf("expected extra sink to receive log output, got %q", buf.String())
+	}
+}
+
+// TestConfigureMultiOutputHonorsFormatOnStderr verifies a "stderr" sink
+// configured via Mode is wrapped in the requested Format (here "logfmt",
+// which never emits raw "{"), rather than always getting raw JSON
+// regardless of Config.Format.
+func TestConfigureMultiOutputHonorsFormatOnStderr(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("os.Pipe() error = %v", err)
+	}
+
+	originalStderr := os.Stderr
+	os.Stderr = w
+	defer func() { os.Stderr = originalStderr }()
+
+	logging.ConfigureCmdLogger(logging.Config{
+		Level:  "info",
+		Mode:   "stderr",
+		Format: logging.FormatLogfmt,
+	})
+
+	log.Info().Msg("logfmt on stderr")
+
+	_ = w.Close()
+	os.Stderr = originalStderr
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("error reading captured stderr: %v", err)
+	}
+
+	if bytes.Contains(data, []byte("{")) {
+		t.Errorf("expected logfmt (no JSON braces), got %q", data)
+	}
+	if !bytes.Contains(data, []byte("logfmt on stderr")) {
+		t.Errorf("expected message in output, got %q", data)
+	}
+}
+
+// TestWithFormat verifies WithFormat overrides Config.Format.
+func TestWithFormat(t *testing.T) {
+	var buf bytes.Buffer
+
+	logging.ConfigureCmdLogger(logging.Config{Level: "info"}, logging.WithFormat(logging.FormatJSON), logging.WithSink(&buf))
+
+	log.Info().Msg("json formatted")
+
+	if !bytes.Contains(buf.Bytes(), []byte(`"message":"json formatted"`)) {
+		t.Errorf("expected raw JSON output, got %q", buf.String())
+	}
+}
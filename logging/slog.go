@@ -0,0 +1,130 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+// APIZerolog and APISlog are the supported values of Config.API.
+const (
+	APIZerolog = "zerolog"
+	APISlog    = "slog"
+)
+
+// slogHandler bridges slog.Record events into a zerolog.Logger, so
+// callers can adopt log/slog incrementally while the file rotation, TTY
+// pretty-printing and multi-sink fan-out configured via
+// ConfigureLogOutput/ConfigureMultiOutput keep working unchanged.
+type slogHandler struct {
+	logger zerolog.Logger
+	group  string
+	attrs  []groupedAttr
+}
+
+// groupedAttr pairs an attr bound via WithAttrs with the group that was
+// active when it was bound, so Handle can qualify it correctly even after
+// subsequent WithGroup calls change h.group.
+type groupedAttr struct {
+	group string
+	attr  slog.Attr
+}
+
+// NewSlogHandler configures the zerolog sink for cfg, exactly as
+// ConfigureCmdLogger does, and returns an slog.Handler that writes
+// through it. The global zerolog logger remains usable at the same time,
+// so an application can migrate to log/slog one call site at a time.
+func NewSlogHandler(cfg Config, opts ...Option) slog.Handler {
+	for _, o := range opts {
+		o(&cfg)
+	}
+
+	ConfigureCmdLogger(cfg)
+
+	return newSlogHandlerFromLogger(log.Logger)
+}
+
+func newSlogHandlerFromLogger(logger zerolog.Logger) *slogHandler {
+	return &slogHandler{logger: logger}
+}
+
+// SetDefaultSlog installs NewSlogHandler(cfg, opts...) as the default
+// slog.Logger via slog.SetDefault.
+func SetDefaultSlog(cfg Config, opts ...Option) {
+	slog.SetDefault(slog.New(NewSlogHandler(cfg, opts...)))
+}
+
+func (h *slogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return h.logger.GetLevel() <= slogToZerologLevel(level)
+}
+
+func (h *slogHandler) Handle(_ context.Context, record slog.Record) error {
+	event := h.logger.WithLevel(slogToZerologLevel(record.Level))
+
+	for _, ga := range h.attrs {
+		event = addSlogAttr(event, ga.group, ga.attr)
+	}
+
+	record.Attrs(func(attr slog.Attr) bool {
+		event = addSlogAttr(event, h.group, attr)
+		return true
+	})
+
+	event.Msg(record.Message)
+
+	return nil
+}
+
+func (h *slogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	newAttrs := make([]groupedAttr, 0, len(h.attrs)+len(attrs))
+	newAttrs = append(newAttrs, h.attrs...)
+	for _, attr := range attrs {
+		newAttrs = append(newAttrs, groupedAttr{group: h.group, attr: attr})
+	}
+
+	return &slogHandler{logger: h.logger, group: h.group, attrs: newAttrs}
+}
+
+func (h *slogHandler) WithGroup(name string) slog.Handler {
+	group := name
+	if h.group != "" {
+		group = h.group + "." + name
+	}
+
+	return &slogHandler{logger: h.logger, group: group, attrs: h.attrs}
+}
+
+// addSlogAttr attaches attr to event, qualifying its key with prefix and
+// flattening nested slog.Group values into dotted keys.
+func addSlogAttr(event *zerolog.Event, prefix string, attr slog.Attr) *zerolog.Event {
+	attr.Value = attr.Value.Resolve()
+
+	key := attr.Key
+	if prefix != "" {
+		key = prefix + "." + key
+	}
+
+	if attr.Value.Kind() == slog.KindGroup {
+		for _, a := range attr.Value.Group() {
+			event = addSlogAttr(event, key, a)
+		}
+		return event
+	}
+
+	return event.Interface(key, attr.Value.Any())
+}
+
+func slogToZerologLevel(level slog.Level) zerolog.Level {
+	switch {
+	case level >= slog.LevelError:
+		return zerolog.ErrorLevel
+	case level >= slog.LevelWarn:
+		return zerolog.WarnLevel
+	case level >= slog.LevelInfo:
+		return zerolog.InfoLevel
+	default:
+		return zerolog.DebugLevel
+	}
+}
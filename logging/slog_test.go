@@ -0,0 +1,56 @@
+package logging_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/dioad/cli/logging"
+)
+
+// TestNewSlogHandler verifies the handler can be used as a slog.Logger
+// without panicking and reports itself enabled at the configured level.
+func TestNewSlogHandler(t *testing.T) {
+	handler := logging.NewSlogHandler(logging.Config{Level: "debug"})
+
+	logger := slog.New(handler)
+	logger.Info("test message", "key", "value")
+
+	if !handler.Enabled(context.Background(), slog.LevelInfo) {
+		t.Error("expected handler to be enabled at info level")
+	}
+}
+
+// TestSlogHandlerWithAttrsAndGroup verifies WithAttrs/WithGroup return
+// usable handlers and don't panic when logging nested groups.
+func TestSlogHandlerWithAttrsAndGroup(t *testing.T) {
+	handler := logging.NewSlogHandler(logging.Config{Level: "debug"})
+
+	logger := slog.New(handler).With("request_id", "abc123").WithGroup("http")
+	logger.Info("handled request", slog.Group("response", slog.Int("status", 200)))
+}
+
+// TestSlogHandlerWithGroupQualifiesAttrsBoundAfterIt verifies an attr bound
+// via With after WithGroup is nested under that group (here as the dotted
+// key "http.request_id"), rather than landing on an unqualified top-level
+// key regardless of the active group.
+func TestSlogHandlerWithGroupQualifiesAttrsBoundAfterIt(t *testing.T) {
+	var buf bytes.Buffer
+
+	handler := logging.NewSlogHandler(logging.Config{Level: "debug", Format: logging.FormatJSON}, logging.WithSink(&buf))
+
+	logger := slog.New(handler).WithGroup("http").With("request_id", "abc123")
+	logger.Info("handled request")
+
+	if !bytes.Contains(buf.Bytes(), []byte(`"http.request_id":"abc123"`)) {
+		t.Errorf("expected request_id to be nested under http group, got %q", buf.String())
+	}
+}
+
+// TestSetDefaultSlog verifies it installs a usable default slog.Logger.
+func TestSetDefaultSlog(t *testing.T) {
+	logging.SetDefaultSlog(logging.Config{Level: "info"})
+
+	slog.Default().Info("via default logger")
+}
@@ -0,0 +1,13 @@
+//go:build windows || plan9
+
+package logging
+
+import (
+	"fmt"
+	"io"
+)
+
+// newSyslogWriter is unsupported on platforms without log/syslog.
+func newSyslogWriter(oc OutputConfig) (io.Writer, error) {
+	return nil, fmt.Errorf("syslog output is not supported on this platform")
+}
@@ -0,0 +1,111 @@
+//go:build !windows && !plan9
+
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/syslog"
+)
+
+// syslogWriter forwards zerolog's JSON events to syslog at the severity
+// matching each event's "level" field, rather than one fixed severity for
+// the writer's lifetime.
+type syslogWriter struct {
+	w *syslog.Writer
+}
+
+// newSyslogWriter connects to the local syslog daemon using oc.Facility
+// (default LOG_USER) and oc.Tag (default "app") as the syslog tag.
+func newSyslogWriter(oc OutputConfig) (io.Writer, error) {
+	facility, err := parseSyslogFacility(oc.Facility)
+	if err != nil {
+		return nil, err
+	}
+
+	tag := oc.Tag
+	if tag == "" {
+		tag = "app"
+	}
+
+	w, err := syslog.New(facility|syslog.LOG_INFO, tag)
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to syslog: %w", err)
+	}
+
+	return &syslogWriter{w: w}, nil
+}
+
+// Write parses p's "level" field and sends it to syslog via the matching
+// severity method (Debug, Info, Warning, Err, Crit, Emerg), so errors and
+// warnings actually show up as such rather than all being logged at a
+// single fixed priority.
+func (s *syslogWriter) Write(p []byte) (int, error) {
+	var event struct {
+		Level string `json:"level"`
+	}
+	_ = json.Unmarshal(bytes.TrimSpace(p), &event)
+
+	msg := string(p)
+
+	var err error
+	switch event.Level {
+	case "trace", "debug":
+		err = s.w.Debug(msg)
+	case "info":
+		err = s.w.Info(msg)
+	case "warn":
+		err = s.w.Warning(msg)
+	case "error":
+		err = s.w.Err(msg)
+	case "fatal":
+		err = s.w.Crit(msg)
+	case "panic":
+		err = s.w.Emerg(msg)
+	default:
+		err = s.w.Info(msg)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("error sending log event to syslog: %w", err)
+	}
+
+	return len(p), nil
+}
+
+func parseSyslogFacility(name string) (syslog.Priority, error) {
+	if name == "" {
+		return syslog.LOG_USER, nil
+	}
+
+	facilities := map[string]syslog.Priority{
+		"kern":     syslog.LOG_KERN,
+		"user":     syslog.LOG_USER,
+		"mail":     syslog.LOG_MAIL,
+		"daemon":   syslog.LOG_DAEMON,
+		"auth":     syslog.LOG_AUTH,
+		"syslog":   syslog.LOG_SYSLOG,
+		"lpr":      syslog.LOG_LPR,
+		"news":     syslog.LOG_NEWS,
+		"uucp":     syslog.LOG_UUCP,
+		"cron":     syslog.LOG_CRON,
+		"authpriv": syslog.LOG_AUTHPRIV,
+		"ftp":      syslog.LOG_FTP,
+		"local0":   syslog.LOG_LOCAL0,
+		"local1":   syslog.LOG_LOCAL1,
+		"local2":   syslog.LOG_LOCAL2,
+		"local3":   syslog.LOG_LOCAL3,
+		"local4":   syslog.LOG_LOCAL4,
+		"local5":   syslog.LOG_LOCAL5,
+		"local6":   syslog.LOG_LOCAL6,
+		"local7":   syslog.LOG_LOCAL7,
+	}
+
+	facility, ok := facilities[name]
+	if !ok {
+		return 0, fmt.Errorf("unknown syslog facility %q", name)
+	}
+
+	return facility, nil
+}
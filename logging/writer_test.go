@@ -0,0 +1,62 @@
+package logging_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/dioad/cli/logging"
+)
+
+// TestConfigureMultiOutputNoOutputs verifies the function is a no-op when
+// no outputs are configured.
+func TestConfigureMultiOutputNoOutputs(t *testing.T) {
+	if err := logging.ConfigureMultiOutput(logging.Config{}); err != nil {
+		t.Errorf("ConfigureMultiOutput() error = %v, want nil", err)
+	}
+}
+
+// TestConfigureMultiOutputFileAndStdout verifies a file+stdout combination,
+// each sink with its own level, is accepted without error.
+func TestConfigureMultiOutputFileAndStdout(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "app.log")
+
+	cfg := logging.Config{
+		Level: "info",
+		Outputs: []logging.OutputConfig{
+			{Type: "stdout"},
+			{Type: "file", File: logFile, Level: "warn"},
+		},
+	}
+
+	if err := logging.ConfigureMultiOutput(cfg); err != nil {
+		t.Fatalf("ConfigureMultiOutput() error = %v", err)
+	}
+}
+
+// TestConfigureMultiOutputUnknownType verifies an unknown sink type is
+// reported as an error rather than silently ignored.
+func TestConfigureMultiOutputUnknownType(t *testing.T) {
+	cfg := logging.Config{
+		Outputs: []logging.OutputConfig{
+			{Type: "carrier-pigeon"},
+		},
+	}
+
+	if err := logging.ConfigureMultiOutput(cfg); err == nil {
+		t.Error("ConfigureMultiOutput() error = nil, want error for unknown output type")
+	}
+}
+
+// TestConfigureMultiOutputInvalidLevel verifies a bad per-sink level is
+// reported as an error.
+func TestConfigureMultiOutputInvalidLevel(t *testing.T) {
+	cfg := logging.Config{
+		Outputs: []logging.OutputConfig{
+			{Type: "stdout", Level: "not-a-level"},
+		},
+	}
+
+	if err := logging.ConfigureMultiOutput(cfg); err == nil {
+		t.Error("ConfigureMultiOutput() error = nil, want error for invalid level")
+	}
+}
@@ -0,0 +1,63 @@
+package cli
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// PrepareBase wires cmd with the conventional persistent flags
+// (--config/-c, --root/-r, --log-level, --log-file, --log-format), sets
+// viper's env prefix from appName with a "-"/"." -> "_" replacer, and
+// chains a PersistentPreRunE that binds the parsed flag set and
+// unmarshals the merged configuration into cfg before any subcommand's
+// RunE fires. It mirrors tendermint's cli.PrepareBaseCmd and coexists with
+// NewCommand/CobraRunEWithConfig, feeding the same CommonConfig pipeline.
+// --log-level, --log-file and --log-format are bound to the nested
+// log.level/log.file/log.format viper keys that CommonConfig.Logging
+// decodes from, so they actually reach cfg's Logging block rather than
+// landing on an unused flat "log-level" key.
+//
+// The returned func executes cmd and is intended to be called from main:
+//
+//	execute := cli.PrepareBase(rootCmd, "myorg", "myapp", cfg)
+//	if err := execute(); err != nil {
+//		os.Exit(1)
+//	}
+func PrepareBase(cmd *cobra.Command, orgName, appName string, cfg interface{}) func() error {
+	cmd.PersistentFlags().StringP("config", "c", "", "config file")
+	cmd.PersistentFlags().StringP("root", "r", "", "additional directory to search for the config file")
+	cmd.PersistentFlags().String("log-level", "", "log level (trace, debug, info, warn, error)")
+	cmd.PersistentFlags().String("log-file", "", "log file path")
+	cmd.PersistentFlags().String("log-format", "", "log format (console, json, logfmt)")
+
+	_ = viper.BindPFlag("log.level", cmd.PersistentFlags().Lookup("log-level"))
+	_ = viper.BindPFlag("log.file", cmd.PersistentFlags().Lookup("log-file"))
+	_ = viper.BindPFlag("log.format", cmd.PersistentFlags().Lookup("log-format"))
+
+	viper.SetEnvPrefix(appName)
+	viper.SetEnvKeyReplacer(strings.NewReplacer("-", "_", ".", "_"))
+	viper.AutomaticEnv()
+
+	previousPersistentPreRunE := cmd.PersistentPreRunE
+
+	cmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		if previousPersistentPreRunE != nil {
+			if err := previousPersistentPreRunE(cmd, args); err != nil {
+				return err
+			}
+		}
+
+		if root, _ := cmd.Flags().GetString("root"); root != "" {
+			viper.AddConfigPath(root)
+		}
+
+		configFile, _ := cmd.Flags().GetString("config")
+
+		_, err := InitConfig(orgName, appName, cmd, configFile, cfg)
+		return err
+	}
+
+	return cmd.Execute
+}
@@ -0,0 +1,82 @@
+package cli_test
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dioad/cli"
+)
+
+// TestPrepareBaseRegistersPersistentFlags verifies the conventional flags
+// are added to the command.
+func TestPrepareBaseRegistersPersistentFlags(t *testing.T) {
+	type AppConfig struct {
+		Name string `mapstructure:"name"`
+	}
+
+	cmd := &cobra.Command{Use: "test"}
+	cfg := &AppConfig{}
+
+	cli.PrepareBase(cmd, "testorg", "testapp", cfg)
+
+	for _, name := range []string{"config", "root", "log-level", "log-file", "log-format"} {
+		if cmd.PersistentFlags().Lookup(name) == nil {
+			t.Errorf("expected persistent flag %q to be registered", name)
+		}
+	}
+}
+
+// TestPrepareBaseChainsExistingPersistentPreRunE verifies a previously set
+// PersistentPreRunE is still invoked.
+func TestPrepareBaseChainsExistingPersistentPreRunE(t *testing.T) {
+	type AppConfig struct {
+		Name string `mapstructure:"name"`
+	}
+
+	cmd := &cobra.Command{Use: "test"}
+
+	called := false
+	cmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		called = true
+		return nil
+	}
+
+	cfg := &AppConfig{}
+	cli.PrepareBase(cmd, "testorg", "testapp", cfg)
+
+	err := cmd.Flags().Parse(nil)
+	assert.NoError(t, err)
+
+	err = cmd.PersistentPreRunE(cmd, nil)
+	assert.NoError(t, err)
+	assert.True(t, called, "expected the original PersistentPreRunE to run")
+}
+
+// TestPrepareBaseBindsLogFlagsToNestedConfig verifies --log-level actually
+// reaches cfg.Logging.Level via the nested "log.level" viper key that
+// CommonConfig.Logging decodes from, not just a flat "log-level" key
+// nothing reads.
+func TestPrepareBaseBindsLogFlagsToNestedConfig(t *testing.T) {
+	viper.Reset()
+
+	type AppConfig struct {
+		cli.CommonConfig
+	}
+
+	cmd := &cobra.Command{Use: "test"}
+	cfg := &AppConfig{}
+
+	cli.PrepareBase(cmd, "testorg", "testapp", cfg)
+
+	err := cmd.ParseFlags([]string{"--log-level=debug", "--log-file=/tmp/app.log"})
+	assert.NoError(t, err)
+
+	err = cmd.PersistentPreRunE(cmd, nil)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "debug", cfg.Logging.Level)
+	assert.Equal(t, "/tmp/app.log", cfg.Logging.File)
+}
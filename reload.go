@@ -0,0 +1,127 @@
+package cli
+
+import (
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/dioad/cli/logging"
+)
+
+// configChangeDebounce absorbs the handful of rapid write events editors
+// and config-management tools tend to emit for a single logical save.
+const configChangeDebounce = 250 * time.Millisecond
+
+// OnConfigChange installs a viper OnConfigChange callback for cmd's
+// config file: on each write (debounced, so a burst of events collapses
+// into one reload) it decodes the file into a fresh *T via UnmarshalConfig,
+// and, if that differs from the current *cfg, calls handler with snapshots
+// of the old and new values. If handler returns nil, *cfg is swapped to the
+// new value; if T embeds CommonConfig and its Logging block changed,
+// logging.ConfigureCmdLogger is re-applied so log level and rotation take
+// effect live. Decode errors and panics inside handler are logged and
+// recovered from, rather than propagated, so a bad config write can't
+// crash a running command.
+//
+// *cfg is swapped in place rather than replaced with a new pointer, so any
+// goroutine other than handler that reads *cfg must hold the returned
+// *sync.Mutex while doing so — the same mutex reloadConfig holds for the
+// swap — or it races with the reload.
+func OnConfigChange[T any](cmd *cobra.Command, cfg *T, handler func(old, new *T) error) *sync.Mutex {
+	mu := &sync.Mutex{}
+	var timer *time.Timer
+
+	viper.OnConfigChange(func(_ fsnotify.Event) {
+		mu.Lock()
+		if timer != nil {
+			timer.Stop()
+		}
+		timer = time.AfterFunc(configChangeDebounce, func() {
+			reloadConfig(cfg, handler, mu)
+		})
+		mu.Unlock()
+	})
+
+	viper.WatchConfig()
+
+	_ = cmd // reserved for future per-command scoping; viper's watcher is global
+
+	return mu
+}
+
+func reloadConfig[T any](cfg *T, handler func(old, new *T) error, mu *sync.Mutex) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Error().Interface("panic", r).Msg("recovered from panic while reloading config")
+		}
+	}()
+
+	var next T
+	if err := UnmarshalConfig(&next); err != nil {
+		log.Warn().Err(err).Msg("error decoding config on change, keeping previous config")
+		return
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	old := *cfg
+
+	if reflect.DeepEqual(old, next) {
+		return
+	}
+
+	if err := handler(&old, &next); err != nil {
+		log.Warn().Err(err).Msg("config change handler rejected new config, keeping previous config")
+		return
+	}
+
+	if oldLogging, newLogging, ok := loggingConfigsOf(&old, &next); ok {
+		if !reflect.DeepEqual(oldLogging, newLogging) {
+			logging.ConfigureCmdLogger(newLogging)
+		}
+	}
+
+	*cfg = next
+
+	log.Info().Msg("reloaded configuration")
+}
+
+// loggingConfigsOf returns the logging.Config of old and new's embedded
+// CommonConfig field, if T embeds one.
+func loggingConfigsOf(old, new interface{}) (logging.Config, logging.Config, bool) {
+	oldCommon, ok := commonConfigOf(old)
+	if !ok {
+		return logging.Config{}, logging.Config{}, false
+	}
+
+	newCommon, ok := commonConfigOf(new)
+	if !ok {
+		return logging.Config{}, logging.Config{}, false
+	}
+
+	return oldCommon.Logging, newCommon.Logging, true
+}
+
+func commonConfigOf(cfg interface{}) (CommonConfig, bool) {
+	v := reflect.ValueOf(cfg)
+	for v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+
+	if v.Kind() != reflect.Struct {
+		return CommonConfig{}, false
+	}
+
+	field := v.FieldByName("CommonConfig")
+	if !field.IsValid() || field.Type() != reflect.TypeOf(CommonConfig{}) {
+		return CommonConfig{}, false
+	}
+
+	return field.Interface().(CommonConfig), true
+}
@@ -0,0 +1,150 @@
+package cli_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dioad/cli"
+)
+
+type reloadTestConfig struct {
+	cli.CommonConfig
+	Name string `mapstructure:"name"`
+}
+
+// TestOnConfigChangeSwapsConfigOnWrite verifies a config file write is
+// decoded, handed to handler, and (since handler returns nil) swapped into
+// cfg.
+func TestOnConfigChangeSwapsConfigOnWrite(t *testing.T) {
+	dir := t.TempDir()
+	configFile := filepath.Join(dir, "app.yaml")
+
+	err := os.WriteFile(configFile, []byte("name: before\nlog:\n  level: info\n"), 0600)
+	assert.NoError(t, err)
+
+	viper.Reset()
+	viper.SetConfigFile(configFile)
+	assert.NoError(t, viper.ReadInConfig())
+
+	cfg := &reloadTestConfig{}
+	assert.NoError(t, cli.UnmarshalConfig(cfg))
+	assert.Equal(t, "before", cfg.Name)
+
+	handlerCalls := make(chan [2]string, 1)
+
+	cmd := &cobra.Command{Use: "test"}
+	mu := cli.OnConfigChange(cmd, cfg, func(old, newCfg *reloadTestConfig) error {
+		handlerCalls <- [2]string{old.Name, newCfg.Name}
+		return nil
+	})
+
+	err = os.WriteFile(configFile, []byte("name: after\nlog:\n  level: debug\n"), 0600)
+	assert.NoError(t, err)
+
+	select {
+	case names := <-handlerCalls:
+		assert.Equal(t, "before", names[0])
+		assert.Equal(t, "after", names[1])
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for config change handler")
+	}
+
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return cfg.Name == "after"
+	}, time.Second, 10*time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, "debug", cfg.Logging.Level)
+}
+
+// TestOnConfigChangeHandlerRejectionKeepsOldConfig verifies cfg is left
+// untouched when handler returns an error.
+func TestOnConfigChangeHandlerRejectionKeepsOldConfig(t *testing.T) {
+	dir := t.TempDir()
+	configFile := filepath.Join(dir, "app.yaml")
+
+	err := os.WriteFile(configFile, []byte("name: before\n"), 0600)
+	assert.NoError(t, err)
+
+	viper.Reset()
+	viper.SetConfigFile(configFile)
+	assert.NoError(t, viper.ReadInConfig())
+
+	cfg := &reloadTestConfig{}
+	assert.NoError(t, cli.UnmarshalConfig(cfg))
+
+	rejected := make(chan struct{}, 1)
+
+	cmd := &cobra.Command{Use: "test"}
+	mu := cli.OnConfigChange(cmd, cfg, func(old, newCfg *reloadTestConfig) error {
+		defer func() { rejected <- struct{}{} }()
+		return assert.AnError
+	})
+
+	err = os.WriteFile(configFile, []byte("name: after\n"), 0600)
+	assert.NoError(t, err)
+
+	select {
+	case <-rejected:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for config change handler")
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, "before", cfg.Name)
+}
+
+// TestOnConfigChangeWithoutCommonConfig verifies T need not embed
+// CommonConfig; no logging reconfiguration is attempted in that case.
+func TestOnConfigChangeWithoutCommonConfig(t *testing.T) {
+	type plainConfig struct {
+		Name string `mapstructure:"name"`
+	}
+
+	dir := t.TempDir()
+	configFile := filepath.Join(dir, "app.yaml")
+
+	err := os.WriteFile(configFile, []byte("name: before\n"), 0600)
+	assert.NoError(t, err)
+
+	viper.Reset()
+	viper.SetConfigFile(configFile)
+	assert.NoError(t, viper.ReadInConfig())
+
+	cfg := &plainConfig{}
+	assert.NoError(t, cli.UnmarshalConfig(cfg))
+
+	done := make(chan struct{}, 1)
+
+	cmd := &cobra.Command{Use: "test"}
+	mu := cli.OnConfigChange(cmd, cfg, func(old, newCfg *plainConfig) error {
+		defer func() { done <- struct{}{} }()
+		return nil
+	})
+
+	err = os.WriteFile(configFile, []byte("name: after\n"), 0600)
+	assert.NoError(t, err)
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for config change handler")
+	}
+
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return cfg.Name == "after"
+	}, time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,206 @@
+package cli
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+// RemoteConfigSource fetches a configuration document from an external
+// source and can notify on changes so long-running commands can
+// hot-reload. Implementations beyond the in-tree HTTPRemoteConfigSource
+// and FileRemoteConfigSource (etcd, consul, ...) can be supplied directly
+// to WithRemoteConfig.
+type RemoteConfigSource interface {
+	// Fetch returns the raw config document and its format ("yaml" or
+	// "json", as accepted by viper.SetConfigType).
+	Fetch(ctx context.Context) ([]byte, string, error)
+
+	// Watch blocks, calling onChange whenever the source's content
+	// changes, until ctx is cancelled.
+	Watch(ctx context.Context, onChange func())
+}
+
+var (
+	remoteConfigSourcesMu sync.Mutex
+	remoteConfigSources   = map[*cobra.Command]RemoteConfigSource{}
+)
+
+// WithRemoteConfig registers source as the remote config for cmd. When the
+// command runs via NewCommand, source is fetched and merged into viper
+// beneath flags, env vars and any config file (including an explicit
+// --config file), and watched for changes for the lifetime of the command.
+func WithRemoteConfig(source RemoteConfigSource) CommandOpt {
+	return func(cmd *cobra.Command) {
+		remoteConfigSourcesMu.Lock()
+		defer remoteConfigSourcesMu.Unlock()
+		remoteConfigSources[cmd] = source
+	}
+}
+
+func remoteConfigSourceFor(cmd *cobra.Command) RemoteConfigSource {
+	remoteConfigSourcesMu.Lock()
+	defer remoteConfigSourcesMu.Unlock()
+	return remoteConfigSources[cmd]
+}
+
+// HTTPRemoteConfigSource fetches a config document over HTTP(S) and polls
+// it for changes.
+type HTTPRemoteConfigSource struct {
+	URL string
+
+	// Format is "yaml" or "json". Inferred from the URL's extension
+	// (defaulting to "yaml") when empty.
+	Format string
+
+	Client *http.Client
+
+	// PollInterval is how often Watch re-fetches URL. Defaults to 30s.
+	PollInterval time.Duration
+}
+
+func (s HTTPRemoteConfigSource) Fetch(ctx context.Context) ([]byte, string, error) {
+	client := s.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.URL, nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("error building request for %s: %w", s.URL, err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, "", fmt.Errorf("error fetching %s: %w", s.URL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("unexpected status %d fetching %s", resp.StatusCode, s.URL)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", fmt.Errorf("error reading response from %s: %w", s.URL, err)
+	}
+
+	return data, s.format(), nil
+}
+
+func (s HTTPRemoteConfigSource) format() string {
+	if s.Format != "" {
+		return s.Format
+	}
+
+	if strings.HasSuffix(s.URL, ".json") {
+		return "json"
+	}
+
+	return "yaml"
+}
+
+func (s HTTPRemoteConfigSource) Watch(ctx context.Context, onChange func()) {
+	interval := s.PollInterval
+	if interval == 0 {
+		interval = 30 * time.Second
+	}
+
+	var lastHash [32]byte
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			data, _, err := s.Fetch(ctx)
+			if err != nil {
+				log.Warn().Err(err).Str("url", s.URL).Msg("error polling remote config")
+				continue
+			}
+
+			hash := sha256.Sum256(data)
+			if hash != lastHash {
+				lastHash = hash
+				onChange()
+			}
+		}
+	}
+}
+
+// FileRemoteConfigSource watches a config file on a mounted volume, e.g. a
+// Kubernetes ConfigMap, polling its modification time for changes.
+type FileRemoteConfigSource struct {
+	Path string
+
+	// Format is inferred from the file's extension (defaulting to
+	// "yaml") when empty.
+	Format string
+
+	// PollInterval is how often Watch checks Path's mtime. Defaults to 5s.
+	PollInterval time.Duration
+}
+
+func (s FileRemoteConfigSource) Fetch(_ context.Context) ([]byte, string, error) {
+	data, err := os.ReadFile(s.Path)
+	if err != nil {
+		return nil, "", fmt.Errorf("error reading %s: %w", s.Path, err)
+	}
+
+	return data, s.format(), nil
+}
+
+func (s FileRemoteConfigSource) format() string {
+	if s.Format != "" {
+		return s.Format
+	}
+
+	if ext := strings.TrimPrefix(filepath.Ext(s.Path), "."); ext != "" {
+		return ext
+	}
+
+	return "yaml"
+}
+
+func (s FileRemoteConfigSource) Watch(ctx context.Context, onChange func()) {
+	interval := s.PollInterval
+	if interval == 0 {
+		interval = 5 * time.Second
+	}
+
+	var lastModTime time.Time
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			info, err := os.Stat(s.Path)
+			if err != nil {
+				log.Warn().Err(err).Str("path", s.Path).Msg("error polling remote config file")
+				continue
+			}
+
+			if info.ModTime().After(lastModTime) {
+				lastModTime = info.ModTime()
+				onChange()
+			}
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package cli_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dioad/cli"
+)
+
+// TestHTTPRemoteConfigSourceFetch verifies a successful fetch returns the
+// body and a format inferred from the URL.
+func TestHTTPRemoteConfigSourceFetch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"name":"test"}`))
+	}))
+	defer server.Close()
+
+	source := cli.HTTPRemoteConfigSource{URL: server.URL + "/config.json"}
+
+	data, format, err := source.Fetch(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "json", format)
+	assert.Contains(t, string(data), "test")
+}
+
+// TestHTTPRemoteConfigSourceFetchError verifies a non-200 response is
+// surfaced as an error.
+func TestHTTPRemoteConfigSourceFetchError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	source := cli.HTTPRemoteConfigSource{URL: server.URL}
+
+	_, _, err := source.Fetch(context.Background())
+	assert.Error(t, err)
+}
+
+// TestFileRemoteConfigSourceFetch verifies reading a local file and
+// inferring its format from the extension.
+func TestFileRemoteConfigSourceFetch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	err := os.WriteFile(path, []byte("name: test\n"), 0600)
+	assert.NoError(t, err)
+
+	source := cli.FileRemoteConfigSource{Path: path}
+
+	data, format, err := source.Fetch(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "yaml", format)
+	assert.Contains(t, string(data), "test")
+}
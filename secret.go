@@ -0,0 +1,120 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/zalando/go-keyring"
+)
+
+// SecretResolver resolves an external secret reference to its plaintext
+// value. ref is everything after the "scheme://" prefix.
+type SecretResolver func(ref string) (string, error)
+
+// secretResolvers maps a URI scheme (e.g. "env", "file") to the resolver
+// used to hydrate it. Populated with the built-in schemes and extended via
+// RegisterSecretResolver.
+var secretResolvers = map[string]SecretResolver{
+	"env":     resolveEnvSecret,
+	"file":    resolveFileSecret,
+	"keyring": resolveKeyringSecret,
+}
+
+// RegisterSecretResolver registers a SecretResolver for the given URI
+// scheme, e.g. "vault" or "aws-sm", so config values of the form
+// "scheme://..." are hydrated from an external secret store during
+// UnmarshalConfig. Registering a scheme that is already known, including
+// the built-in "env", "file" and "keyring" schemes, replaces it.
+func RegisterSecretResolver(scheme string, resolver SecretResolver) {
+	secretResolvers[scheme] = resolver
+}
+
+// resolveEnvSecret resolves "env://VAR" references from the process
+// environment.
+func resolveEnvSecret(ref string) (string, error) {
+	value, ok := os.LookupEnv(ref)
+	if !ok {
+		return "", fmt.Errorf("environment variable %q is not set", ref)
+	}
+
+	return value, nil
+}
+
+// resolveFileSecret resolves "file:///path" references by reading the file
+// contents, trimming a single trailing newline.
+func resolveFileSecret(ref string) (string, error) {
+	data, err := os.ReadFile(ref)
+	if err != nil {
+		return "", fmt.Errorf("error reading secret file %q: %w", ref, err)
+	}
+
+	return strings.TrimSuffix(string(data), "\n"), nil
+}
+
+// resolveKeyringSecret resolves "keyring://service/key" references from the
+// OS keyring.
+func resolveKeyringSecret(ref string) (string, error) {
+	service, key, ok := strings.Cut(ref, "/")
+	if !ok {
+		return "", fmt.Errorf("keyring secret reference %q must be of the form service/key", ref)
+	}
+
+	return keyring.Get(service, key)
+}
+
+// resolveSecretValue resolves value if it is a reference of the form
+// "scheme://rest" matching a registered SecretResolver, otherwise it
+// returns value unchanged.
+func resolveSecretValue(key, value string) (string, error) {
+	scheme, rest, ok := strings.Cut(value, "://")
+	if !ok {
+		return value, nil
+	}
+
+	resolver, ok := secretResolvers[scheme]
+	if !ok {
+		return value, nil
+	}
+
+	resolved, err := resolver(rest)
+	if err != nil {
+		return "", fmt.Errorf("error resolving secret for config key %q: %w", key, err)
+	}
+
+	return resolved, nil
+}
+
+// resolveSecrets walks a viper settings map, as returned by
+// viper.AllSettings, and resolves any string value that matches a
+// registered secret scheme. keyPrefix is the dotted key path of settings,
+// used to name the offending key in resolution errors.
+func resolveSecrets(settings map[string]interface{}, keyPrefix string) (map[string]interface{}, error) {
+	resolved := make(map[string]interface{}, len(settings))
+
+	for k, v := range settings {
+		key := k
+		if keyPrefix != "" {
+			key = keyPrefix + "." + k
+		}
+
+		switch val := v.(type) {
+		case string:
+			r, err := resolveSecretValue(key, val)
+			if err != nil {
+				return nil, err
+			}
+			resolved[k] = r
+		case map[string]interface{}:
+			r, err := resolveSecrets(val, key)
+			if err != nil {
+				return nil, err
+			}
+			resolved[k] = r
+		default:
+			resolved[k] = v
+		}
+	}
+
+	return resolved, nil
+}
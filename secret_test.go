@@ -0,0 +1,86 @@
+package cli_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dioad/cli"
+)
+
+// TestUnmarshalConfigResolvesEnvSecret verifies env:// references are
+// hydrated from the process environment during UnmarshalConfig.
+func TestUnmarshalConfigResolvesEnvSecret(t *testing.T) {
+	t.Setenv("TEST_SECRET_VALUE", "super-secret")
+
+	flags := &pflag.FlagSet{}
+	flags.String("token", "", "")
+	err := flags.Parse([]string{"--token=env://TEST_SECRET_VALUE"})
+	assert.NoError(t, err)
+
+	err = viper.BindPFlags(flags)
+	assert.NoError(t, err)
+
+	type TestConfig struct {
+		Token string `mapstructure:"token"`
+	}
+
+	cfg := &TestConfig{}
+	err = cli.UnmarshalConfig(cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, "super-secret", cfg.Token)
+}
+
+// TestUnmarshalConfigResolvesFileSecret verifies file:// references are
+// hydrated from disk during UnmarshalConfig.
+func TestUnmarshalConfigResolvesFileSecret(t *testing.T) {
+	secretFile := filepath.Join(t.TempDir(), "token")
+	err := os.WriteFile(secretFile, []byte("file-secret\n"), 0600)
+	assert.NoError(t, err)
+
+	flags := &pflag.FlagSet{}
+	flags.String("token", "", "")
+	err = flags.Parse([]string{"--token=file://" + secretFile})
+	assert.NoError(t, err)
+
+	err = viper.BindPFlags(flags)
+	assert.NoError(t, err)
+
+	type TestConfig struct {
+		Token string `mapstructure:"token"`
+	}
+
+	cfg := &TestConfig{}
+	err = cli.UnmarshalConfig(cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, "file-secret", cfg.Token)
+}
+
+// TestRegisterSecretResolver verifies a custom scheme is consulted during
+// UnmarshalConfig.
+func TestRegisterSecretResolver(t *testing.T) {
+	cli.RegisterSecretResolver("vault", func(ref string) (string, error) {
+		return "resolved:" + ref, nil
+	})
+
+	flags := &pflag.FlagSet{}
+	flags.String("token", "", "")
+	err := flags.Parse([]string{"--token=vault://secret/data/token"})
+	assert.NoError(t, err)
+
+	err = viper.BindPFlags(flags)
+	assert.NoError(t, err)
+
+	type TestConfig struct {
+		Token string `mapstructure:"token"`
+	}
+
+	cfg := &TestConfig{}
+	err = cli.UnmarshalConfig(cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, "resolved:secret/data/token", cfg.Token)
+}
@@ -0,0 +1,107 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+type shutdownGroupContextKey struct{}
+
+// ShutdownGroup collects cleanup funcs registered by a running command so
+// they can be run, with a shared timeout, once a shutdown signal arrives.
+// Use ShutdownGroupFromContext to retrieve the one installed by
+// WithGracefulShutdown.
+type ShutdownGroup struct {
+	mu    sync.Mutex
+	funcs []func(context.Context) error
+}
+
+// Register adds fn to be run during the graceful shutdown timeout window.
+func (g *ShutdownGroup) Register(fn func(context.Context) error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.funcs = append(g.funcs, fn)
+}
+
+func (g *ShutdownGroup) runWithTimeout(timeout time.Duration) {
+	ctx := context.Background()
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	g.mu.Lock()
+	funcs := append([]func(context.Context) error(nil), g.funcs...)
+	g.mu.Unlock()
+
+	var wg sync.WaitGroup
+	for _, fn := range funcs {
+		wg.Add(1)
+		go func(fn func(context.Context) error) {
+			defer wg.Done()
+			if err := fn(ctx); err != nil {
+				log.Error().Err(err).Msg("error during graceful shutdown cleanup")
+			}
+		}(fn)
+	}
+	wg.Wait()
+}
+
+// ShutdownGroupFromContext returns the ShutdownGroup installed by
+// WithGracefulShutdown, or nil if the command wasn't built with that
+// option.
+func ShutdownGroupFromContext(ctx context.Context) *ShutdownGroup {
+	group, _ := ctx.Value(shutdownGroupContextKey{}).(*ShutdownGroup)
+	return group
+}
+
+// WithGracefulShutdown wraps cmd's context with signal.NotifyContext for
+// os.Interrupt and syscall.SIGTERM: on the first signal the context is
+// cancelled and any funcs registered on the command's ShutdownGroup are
+// run, up to timeout; on a second signal the process exits immediately
+// with code 130.
+func WithGracefulShutdown(timeout time.Duration) CommandOpt {
+	return func(cmd *cobra.Command) {
+		previousPreRunE := cmd.PreRunE
+
+		cmd.PreRunE = func(cmd *cobra.Command, args []string) error {
+			if previousPreRunE != nil {
+				if err := previousPreRunE(cmd, args); err != nil {
+					return err
+				}
+			}
+
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+
+			group := &ShutdownGroup{}
+			ctx = context.WithValue(ctx, shutdownGroupContextKey{}, group)
+			cmd.SetContext(ctx)
+
+			go func() {
+				<-ctx.Done()
+				stop()
+
+				forceExit := make(chan os.Signal, 1)
+				signal.Notify(forceExit, os.Interrupt, syscall.SIGTERM)
+				defer signal.Stop(forceExit)
+
+				go func() {
+					<-forceExit
+					os.Exit(130)
+				}()
+
+				group.runWithTimeout(timeout)
+			}()
+
+			return nil
+		}
+	}
+}
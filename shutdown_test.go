@@ -0,0 +1,40 @@
+package cli_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dioad/cli"
+)
+
+// TestWithGracefulShutdownInstallsShutdownGroup verifies the option wires
+// up a ShutdownGroup retrievable from the command's context once PreRunE
+// has run.
+func TestWithGracefulShutdownInstallsShutdownGroup(t *testing.T) {
+	cmd := &cobra.Command{Use: "test"}
+	cmd.SetContext(context.Background())
+
+	opt := cli.WithGracefulShutdown(0)
+	opt(cmd)
+
+	assert.NotNil(t, cmd.PreRunE)
+
+	err := cmd.PreRunE(cmd, nil)
+	assert.NoError(t, err)
+
+	group := cli.ShutdownGroupFromContext(cmd.Context())
+	assert.NotNil(t, group)
+
+	// Registering a cleanup func should not panic even though nothing
+	// triggers shutdown in this test.
+	group.Register(func(ctx context.Context) error { return nil })
+}
+
+// TestShutdownGroupFromContextMissing verifies a context without a
+// ShutdownGroup returns nil rather than panicking.
+func TestShutdownGroupFromContextMissing(t *testing.T) {
+	assert.Nil(t, cli.ShutdownGroupFromContext(context.Background()))
+}